@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/rs/zerolog/log"
@@ -26,6 +27,8 @@ type Config struct {
 
 	Torrent TorrentConf `envPrefix:"TORRENT_" yaml:"torrent,omitempty"`
 	Sub     SubConf     `envPrefix:"SUB_" yaml:"sub,omitempty"`
+	Watch   WatchConf   `envPrefix:"WATCH_" yaml:"watch,omitempty"`
+	Search  SearchConf  `envPrefix:"SEARCH_" yaml:"search,omitempty"`
 }
 
 func (conf *Config) defaults() {
@@ -123,8 +126,33 @@ func defaultTo(a interface{}, def interface{}) {
 }
 
 type TorrentConf struct {
-	DB serve.DatabaseConf `envPrefix:"DB_" yaml:"db,omitempty"`
+	DB       serve.DatabaseConf `envPrefix:"DB_" yaml:"db,omitempty"`
+	Trackers []string           `env:"TRACKERS" envSeparator:"," yaml:"trackers,omitempty"`  // used to find peers of magnet without tracker
+	Lint     string             `env:"LINT" yaml:"lint,omitempty"`                           // record, reject or quarantine invalid torrent
+	Verify   []string           `env:"VERIFY_DIRS" envSeparator:"," yaml:"verify,omitempty"` // dirs allowed to verify local data by api
+	Create   []string           `env:"CREATE_DIRS" envSeparator:"," yaml:"create,omitempty"` // dirs allowed to create torrent by api
+	Dedup    DedupConf          `envPrefix:"DEDUP_" yaml:"dedup,omitempty"`
+}
+
+// DedupConf cluster duplicate torrents periodically
+type DedupConf struct {
+	Interval time.Duration `env:"INTERVAL" envDefault:"6h" yaml:"interval,omitempty"` // 0 to disable
+	MinSize  int64         `env:"MIN_SIZE" yaml:"min_size,omitempty"`
+}
+
+// SearchConf keep search index in sync with indexed torrents when serving
+type SearchConf struct {
+	Live     bool          `env:"LIVE" envDefault:"true" yaml:"live,omitempty"`       // server own the index writer, search index command can not run meanwhile
+	Interval time.Duration `env:"INTERVAL" envDefault:"1m" yaml:"interval,omitempty"` // poll changes from other process
+	Pinyin   bool          `env:"PINYIN" envDefault:"true" yaml:"pinyin,omitempty"`   // match han names by pinyin, changing it reindex all torrents
+}
+
+// WatchConf watch dirs for dropped torrent, archive and subtitle files
+type WatchConf struct {
+	Dirs     []string      `env:"DIRS" envSeparator:"," yaml:"dirs,omitempty"`
+	Interval time.Duration `env:"INTERVAL" envDefault:"10s" yaml:"interval,omitempty"`
 }
+
 type SubConf struct {
 	DB serve.DatabaseConf `envPrefix:"DB_" yaml:"db,omitempty"`
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+func createTorrent(ctx *cli.Context) (err error) {
+	if ctx.NArg() != 1 {
+		return errors.New("require one file or dir")
+	}
+	path := ctx.Args().First()
+	o := torrenti.CreateTorrentOptions{
+		Path:      path,
+		Name:      ctx.String("name"),
+		Version:   ctx.String("version"),
+		Trackers:  torrenti.ParseTrackerTiers(ctx.StringSlice("tracker")),
+		WebSeeds:  ctx.StringSlice("web-seed"),
+		Private:   ctx.Bool("private"),
+		Source:    ctx.String("source"),
+		Comment:   ctx.String("comment"),
+		CreatedBy: Name,
+	}
+	if !ctx.Bool("no-date") {
+		o.CreationDate = time.Now()
+	}
+	if v := ctx.String("piece-length"); v != "" {
+		n, err := humanize.ParseBytes(v)
+		if err != nil {
+			return errors.Wrap(err, "invalid piece length")
+		}
+		o.PieceLength = int64(n)
+	}
+	out := ctx.String("output")
+	if out == "" {
+		name := o.Name
+		if name == "" {
+			name = filepath.Base(filepath.Clean(path))
+		}
+		out = torrenti.SafeFilename(name) + ".torrent"
+	}
+	if out == "-" {
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	}
+
+	data, err := torrenti.CreateTorrent(ctx.Context, o)
+	if err != nil {
+		return
+	}
+	t := &torrenti.Torrent{Data: data, FileInfo: &util.File{Path: filepath.Base(out), Length: int64(len(data))}}
+	if err = t.LoadContext(ctx.Context); err != nil {
+		return
+	}
+	if out == "-" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(out, data, 0o644)
+	}
+	if err != nil {
+		return
+	}
+	l := log.Info().Str("hash", t.Hash.String()).Str("output", out)
+	if !t.HashV2.IsZero() {
+		l = l.Str("hash_v2", t.HashV2.String())
+	}
+	l.Msg("created")
+
+	if ctx.Bool("index") {
+		_, err = getTorrentIndexer().IndexTorrent(ctx.Context, t)
+	}
+	return
+}
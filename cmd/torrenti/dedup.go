@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/serve"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+)
+
+func dedupTorrent(ctx *cli.Context) (err error) {
+	minSize := _conf.Torrent.Dedup.MinSize
+	if ctx.IsSet("min-size") {
+		minSize = ctx.Int64("min-size")
+	}
+	_, err = getTorrentIndexer().ClusterDuplicates(ctx.Context, torrenti.ClusterDuplicatesOptions{MinSize: minSize})
+	return
+}
+
+func serveDedup(sc *serve.Context) (err error) {
+	conf := _conf.Torrent.Dedup
+	if conf.Interval <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(sc.Context)
+	sc.G.Add(func() error {
+		t := time.NewTicker(conf.Interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-t.C:
+			}
+			if _, err := getTorrentIndexer().ClusterDuplicates(ctx, torrenti.ClusterDuplicatesOptions{MinSize: conf.MinSize}); err != nil && ctx.Err() == nil {
+				log.Err(err).Msg("cluster duplicates")
+			}
+		}
+	}, func(err error) {
+		cancel()
+	})
+	return
+}
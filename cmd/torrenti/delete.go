@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	torrentiv1 "github.com/wenerme/torrenti/pkg/apis/media/torrenti/v1"
+	"github.com/wenerme/torrenti/pkg/search"
+	"github.com/wenerme/torrenti/pkg/torrenti/services"
+)
+
+func deleteTorrent(cc *cli.Context) (err error) {
+	svr := &services.TorrentIndexerServer{Indexer: getTorrentIndexer()}
+	ss, err := search.NewService(search.NewServiceOptions{
+		DataDir: filepath.Join(_conf.DataDir, "search"),
+	})
+	switch {
+	case err == nil:
+		svr.Search = ss
+	case cc.String("query") != "":
+		return err
+	default:
+		log.Debug().Err(err).Msg("open search index")
+	}
+
+	resp, err := svr.DeleteTorrent(cc.Context, &torrentiv1.DeleteTorrentRequest{
+		Hashes:        cc.Args().Slice(),
+		ContentHashes: cc.StringSlice("content-hash"),
+		Query:         cc.String("query"),
+		Reason:        cc.String("reason"),
+	})
+	if err != nil {
+		return
+	}
+	for _, v := range resp.Hashes {
+		fmt.Println(v)
+	}
+	return
+}
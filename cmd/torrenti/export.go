@@ -0,0 +1,101 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/search"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+func exportTorrent(cc *cli.Context) (err error) {
+	o := torrenti.ExportOptions{
+		RefererDomain: cc.String("referer"),
+		Limit:         cc.Int("limit"),
+	}
+	if cc.NArg() > 0 {
+		o.Hashes = cc.Args().Slice()
+	}
+	if v := cc.String("since"); v != "" {
+		if o.Since, err = util.ParseTime(v); err != nil {
+			return
+		}
+	}
+	if v := cc.String("until"); v != "" {
+		if o.Until, err = util.ParseTime(v); err != nil {
+			return
+		}
+	}
+	if q := cc.String("search"); q != "" {
+		var ss *search.Service
+		ss, err = search.NewService(search.NewServiceOptions{
+			DataDir: filepath.Join(_conf.DataDir, "search"),
+		})
+		if err != nil {
+			return errors.Wrap(err, "open search")
+		}
+		limit := o.Limit
+		if limit <= 0 {
+			limit = 10000
+		}
+		var hashes []string
+		if hashes, err = torrenti.SearchTorrentHashes(cc.Context, ss, q, limit); err != nil {
+			return
+		}
+		o.Hashes = torrenti.SearchedHashes(o.Hashes, hashes)
+	}
+
+	output := cc.String("output")
+	format := cc.String("format")
+	if format == "" {
+		switch {
+		case strings.HasSuffix(output, ".zip"):
+			format = "zip"
+		case strings.HasSuffix(output, ".tar"):
+			format = "tar"
+		case output == "-":
+			format = "tar"
+		default:
+			format = "dir"
+		}
+	}
+
+	var w io.Writer
+	switch {
+	case format == "dir":
+	case output == "-":
+		w = os.Stdout
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	default:
+		var f *os.File
+		if f, err = os.Create(output); err != nil {
+			return
+		}
+		defer f.Close()
+		w = f
+	}
+	ew, err := torrenti.NewExportWriter(format, w, output)
+	if err != nil {
+		return
+	}
+
+	n := 0
+	err = getTorrentIndexer().ExportTorrent(cc.Context, o, func(f *torrenti.ExportFile) error {
+		n++
+		log.Debug().Str("file", f.Name).Msg("export torrent")
+		return ew.WriteFile(f)
+	})
+	if err == nil {
+		err = ew.Close()
+	}
+	log.Info().Int("count", n).Str("output", output).Msg("exported")
+	return
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+)
+
+func findFileTorrent(ctx *cli.Context) (err error) {
+	if ctx.NArg() == 0 {
+		return errors.New("no file to find")
+	}
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+
+	db := getTorrentIndexer().DB
+	for _, fn := range ctx.Args().Slice() {
+		root, err := torrenti.FilePiecesRoot(ctx.Context, fn)
+		if err != nil {
+			return err
+		}
+		if root == nil {
+			log.Warn().Str("file", fn).Msg("skip empty file")
+			continue
+		}
+		out, err := torrenti.FindFileRoot(ctx.Context, db, hex.EncodeToString(root), 0, ctx.Int("limit"))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%x\t%s\t%v\n", root, fn, len(out))
+		for _, v := range out {
+			fmt.Printf("\t%s\t%s\t%s\n", v.Torrent.Hash, v.Torrent.Name, v.File.Path)
+		}
+	}
+	return
+}
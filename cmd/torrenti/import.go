@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/indexer"
+)
+
+func importTorrent(ctx *cli.Context) (err error) {
+	if ctx.NArg() == 0 {
+		return errors.New("no dir or archive to import")
+	}
+	format := ctx.String("format")
+	if format != "text" && format != "json" {
+		return errors.Errorf("invalid format: %q", format)
+	}
+	// keep stdout for result
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+
+	im := &indexer.Importer{
+		Torrent: getTorrentIndexer(),
+		Workers: ctx.Int("workers"),
+	}
+	var onResult func(r *indexer.ImportResult)
+	if format == "text" {
+		onResult = func(r *indexer.ImportResult) {
+			switch r.Status {
+			case indexer.ImportStatusSkipped:
+			case indexer.ImportStatusFailed:
+				fmt.Printf("%s\t%s\t%s\n", r.Status, r.Path, r.Error)
+			default:
+				fmt.Printf("%s\t%s\t%s\n", r.Status, r.Path, r.Hash)
+			}
+		}
+	}
+	sum, err := im.Import(ctx.Context, ctx.Args().Slice(), onResult)
+	if sum == nil {
+		return
+	}
+	log.Info().
+		Int("new", sum.New).Int("duplicate", sum.Duplicate).Int("quarantined", sum.Quarantined).
+		Int("failed", sum.Failed).Int("skipped", sum.Skipped).Int("deleted", sum.Deleted).
+		Msg("imported")
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if jerr := enc.Encode(sum); jerr != nil && err == nil {
+			err = jerr
+		}
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+)
+
+func lintTorrent(ctx *cli.Context) (err error) {
+	if ctx.Bool("stored") {
+		return showStoredFindings(ctx)
+	}
+	errs := 0
+	report := func(name string, findings torrenti.Findings) {
+		if len(findings) == 0 {
+			fmt.Printf("%s: ok\n", name)
+		}
+		for _, v := range findings {
+			fmt.Printf("%s: %s\n", name, v.String())
+			if v.Severity == torrenti.SeverityError {
+				errs++
+			}
+		}
+	}
+	for _, v := range ctx.Args().Slice() {
+		if _, serr := os.Stat(v); serr != nil && !isURL(v) {
+			var mfs []*models.MetaFile
+			if mfs, err = getTorrentIndexer().FindMetaFiles(ctx.Context, v); err != nil {
+				return
+			}
+			if len(mfs) == 0 {
+				return errors.Errorf("torrent not found: %s", v)
+			}
+			for _, mf := range mfs {
+				t, err := getTorrentIndexer().StoredTorrent(ctx.Context, mf)
+				if err != nil {
+					report(mf.Filename, torrenti.Findings{{Severity: torrenti.SeverityError, Code: "load", Message: err.Error()}})
+					continue
+				}
+				report(mf.Filename, torrenti.Lint(t))
+			}
+			continue
+		}
+
+		t, err := torrenti.ParseTorrent(v)
+		if err == nil {
+			err = t.LoadContext(ctx.Context)
+		}
+		if err != nil {
+			report(v, torrenti.Findings{{Severity: torrenti.SeverityError, Code: "load", Message: err.Error()}})
+			continue
+		}
+		report(v, torrenti.Lint(t))
+	}
+	if errs > 0 {
+		return errors.Errorf("found %v errors", errs)
+	}
+	return
+}
+
+func showStoredFindings(ctx *cli.Context) (err error) {
+	db := getTorrentIndexer().DB.WithContext(ctx.Context)
+	var out []models.LintFinding
+	query := db.Order("id")
+	if ctx.NArg() > 0 {
+		var hashes []string
+		for _, v := range ctx.Args().Slice() {
+			hashes = append(hashes, v)
+			if t, err := torrenti.ParseTorrent(v); err == nil && !t.Hash.IsZero() {
+				hashes = append(hashes, t.Hash.String())
+			}
+		}
+		query = query.Where("content_hash in (?) or torrent_hash in (?)", hashes, hashes)
+	}
+	if err = query.Find(&out).Error; err != nil {
+		return
+	}
+	for _, v := range out {
+		f := torrenti.Finding{Severity: torrenti.Severity(v.Severity), Code: v.Code, Path: v.Path, Message: v.Message}
+		fmt.Printf("%s: %s\n", v.TorrentHash, f.String())
+	}
+	return
+}
+
+func isURL(s string) bool {
+	t, err := torrenti.ParseTorrent(s)
+	return err == nil && t.URL != ""
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+)
+
+func getMagnetURL(ctx *cli.Context) error {
+	for _, v := range ctx.Args().Slice() {
+		t, err := torrenti.ParseTorrent(v)
+		if err != nil {
+			return err
+		}
+		var m magnet.Magnet
+		if t.Path != "" || t.URL != "" {
+			if err = t.LoadContext(ctx.Context); err != nil {
+				return err
+			}
+			m = torrenti.NewMagnet(t)
+		} else {
+			m, err = getStoredMagnet(ctx, t.Magnet)
+			if err != nil {
+				return err
+			}
+		}
+		fmt.Println(m.String())
+	}
+	return nil
+}
+
+// getStoredMagnet complete magnet from stored torrent, return as is if not found
+func getStoredMagnet(ctx *cli.Context, m magnet.Magnet) (magnet.Magnet, error) {
+	idx := getTorrentIndexer()
+	var out []*models.Torrent
+	h := m.Hash.String()
+	err := idx.DB.WithContext(ctx.Context).
+		Select([]string{"name", "hash", "hash_v2", "total_file_size"}).
+		Where("hash = ? or hash_v2 = ?", h, h).Limit(1).Find(&out).Error
+	if err != nil {
+		return m, errors.Wrap(err, "find torrent")
+	}
+	if len(out) == 0 {
+		return m, nil
+	}
+	ms, err := torrenti.LoadMagnets(ctx.Context, idx.DB, out)
+	return ms[out[0].Hash], err
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/wenerme/torrenti/pkg/metadata"
 	"github.com/wenerme/torrenti/pkg/serve"
 
 	"github.com/pkg/errors"
@@ -79,6 +80,21 @@ func main() {
 				Name:   "serve",
 				Action: runServer,
 			},
+			{
+				Name:   "reindex",
+				Usage:  "re-derive torrent tables from stored meta files",
+				Action: reindexTorrent,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "batch-size",
+						Value: 100,
+					},
+					&cli.BoolFlag{
+						Name:  "restart",
+						Usage: "ignore checkpoint and start from beginning",
+					},
+				},
+			},
 			{
 				Name: "version",
 				Action: func(c *cli.Context) error {
@@ -113,6 +129,185 @@ func main() {
 						Name:   "add",
 						Usage:  "add to index",
 						Action: addTorrent,
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "re-index if already exists",
+							},
+						},
+					},
+					{
+						Name:      "import",
+						Usage:     "import torrents from dirs and archives recursively",
+						ArgsUsage: "<dir|archive|file>...",
+						Action:    importTorrent,
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "number of index workers, default to number of cpu",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "summary format, text or json",
+								Value: "text",
+							},
+						},
+					},
+					{
+						Name:      "delete",
+						Usage:     "delete torrents and prevent them from being indexed again",
+						ArgsUsage: "[hash...]",
+						Action:    deleteTorrent,
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:  "content-hash",
+								Usage: "content hash of meta file",
+							},
+							&cli.StringFlag{
+								Name:  "query",
+								Usage: "delete search matches, limited to first 1000",
+							},
+							&cli.StringFlag{
+								Name:  "reason",
+								Usage: "reason of deletion",
+							},
+						},
+					},
+					{
+						Name:   "dedup",
+						Usage:  "cluster torrents with same content into duplicate groups",
+						Action: dedupTorrent,
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:  "min-size",
+								Usage: "min size to match, default to 10MiB",
+							},
+						},
+					},
+					{
+						Name:      "create",
+						Usage:     "create torrent from file or dir",
+						ArgsUsage: "<path>",
+						Action:    createTorrent,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "output file, - for stdout, default to <name>.torrent",
+							},
+							&cli.StringFlag{
+								Name:  "name",
+								Usage: "torrent name, default to base name of path",
+							},
+							&cli.StringFlag{
+								Name:  "version",
+								Usage: "v1, v2 or hybrid",
+								Value: torrenti.CreateVersionV1,
+							},
+							&cli.StringFlag{
+								Name:  "piece-length",
+								Usage: "piece length, e.g. 256KiB, auto select if empty",
+							},
+							&cli.StringSliceFlag{
+								Name:  "tracker",
+								Usage: "tracker tier, trackers in tier are separated by comma",
+							},
+							&cli.StringSliceFlag{
+								Name:  "web-seed",
+								Usage: "web seed url",
+							},
+							&cli.BoolFlag{
+								Name: "private",
+							},
+							&cli.StringFlag{
+								Name: "source",
+							},
+							&cli.StringFlag{
+								Name: "comment",
+							},
+							&cli.BoolFlag{
+								Name:  "no-date",
+								Usage: "omit creation date",
+							},
+							&cli.BoolFlag{
+								Name:  "index",
+								Usage: "index created torrent",
+							},
+						},
+					},
+					{
+						Name:      "find-file",
+						Usage:     "find torrents contain the local file by v2 pieces root",
+						ArgsUsage: "<file>...",
+						Action:    findFileTorrent,
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:  "limit",
+								Value: 100,
+							},
+						},
+					},
+					{
+						Name:      "verify",
+						Usage:     "verify local data against indexed torrent",
+						ArgsUsage: "<hash> <path>",
+						Action:    verifyTorrent,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "report format, text or json",
+								Value: "text",
+							},
+						},
+					},
+					{
+						Name:      "lint",
+						Usage:     "validate torrent",
+						ArgsUsage: "<file|url|hash>...",
+						Action:    lintTorrent,
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "stored",
+								Usage: "show stored findings",
+							},
+						},
+					},
+					{
+						Name:      "export",
+						Usage:     "export .torrent files to dir or archive",
+						ArgsUsage: "[hash...]",
+						Action:    exportTorrent,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "output dir or archive file, - for stdout",
+								Value:   "export",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "dir, tar or zip, detect by output by default",
+							},
+							&cli.StringFlag{
+								Name:  "search",
+								Usage: "search query, only matched torrents of hash args are exported when both given",
+							},
+							&cli.StringFlag{
+								Name:  "since",
+								Usage: "indexed since, date or RFC3339",
+							},
+							&cli.StringFlag{
+								Name:  "until",
+								Usage: "indexed before, date or RFC3339",
+							},
+							&cli.StringFlag{
+								Name:  "referer",
+								Usage: "referer domain",
+							},
+							&cli.IntFlag{
+								Name: "limit",
+							},
+						},
 					},
 				},
 			},
@@ -145,8 +340,10 @@ func main() {
 				Name: "magnet",
 				Subcommands: cli.Commands{
 					{
-						Name:  "get-url",
-						Usage: "get manget url of torrent",
+						Name:      "get-url",
+						Usage:     "get manget url of torrent",
+						ArgsUsage: "<file|url|hash|magnet>...",
+						Action:    getMagnetURL,
 					},
 				},
 			},
@@ -297,17 +494,20 @@ func setup(ctx *cli.Context) error {
 
 func addTorrent(ctx *cli.Context) error {
 	idx := getTorrentIndexer()
+	c := metadata.FetcherContextKey.WithValue(ctx.Context, newMetadataFetcher())
 	for _, v := range ctx.Args().Slice() {
 		log.Info().Str("torrent", v).Msg("add torrent")
 		t, err := torrenti.ParseTorrent(v)
 		if err != nil {
 			return err
 		}
-		err = t.Load()
+		err = t.LoadContext(c)
 		if err != nil {
 			return err
 		}
-		_, err = idx.IndexTorrent(ctx.Context, t)
+		_, err = idx.IndexTorrent(c, t, func(o *torrenti.IndexTorrentOptions) {
+			o.Force = ctx.Bool("force")
+		})
 		if err != nil {
 			return err
 		}
@@ -323,6 +523,12 @@ var (
 	_ctx          *cli.Context
 )
 
+func newMetadataFetcher() *metadata.Fetcher {
+	return &metadata.Fetcher{
+		Trackers: _conf.Torrent.Trackers,
+	}
+}
+
 func getTorrentIndexer() *torrenti.Service {
 	_torrentiOnce.Do(_initIndexer)
 	return _torrenti
@@ -340,7 +546,11 @@ func _initIndexer() {
 		panic(err)
 	}
 
-	_torrenti, err = torrenti.NewIndexer(torrenti.NewServiceOptions{DB: gdb})
+	lint, err := torrenti.ParseLintMode(conf.Torrent.Lint)
+	if err != nil {
+		panic(err)
+	}
+	_torrenti, err = torrenti.NewIndexer(torrenti.NewServiceOptions{DB: gdb, Lint: lint})
 	if err != nil {
 		panic(err)
 	}
@@ -399,6 +609,7 @@ func showStat(ctx *cli.Context) error {
 			"files":            ts.TorrentFileCount,
 			"max torrent size": humanize.Bytes(uint64(st.MaxTorrentSize)),
 			"max torrent name": st.MaxTorrentName,
+			"trackers":         ts.TrackerCount,
 		},
 	})
 }
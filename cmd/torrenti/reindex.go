@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+)
+
+func reindexTorrent(ctx *cli.Context) (err error) {
+	p, err := getTorrentIndexer().Reindex(ctx.Context, torrenti.ReindexOptions{
+		BatchSize: ctx.Int("batch-size"),
+		Restart:   ctx.Bool("restart"),
+		OnProgress: func(p *torrenti.ReindexProgress) {
+			log.Info().Uint("position", p.Position).Int64("done", p.Done).Int64("failed", p.Failed).Int64("total", p.Total).Msg("reindex progress")
+		},
+	})
+	if p != nil {
+		log.Info().Uint("position", p.Position).Int64("done", p.Done).Int64("failed", p.Failed).Msg("reindexed")
+	}
+	return
+}
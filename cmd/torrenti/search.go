@@ -8,6 +8,7 @@ import (
 
 	"github.com/blugelabs/bluge/search/highlight"
 	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/samber/lo"
 	"github.com/urfave/cli/v2"
@@ -52,7 +53,7 @@ func runSearchQuery(cc *cli.Context) (err error) {
 				})).
 				Select([]string{"id", "filename", "content_hash", "torrent_hash", "creation_date"}).
 				Preload("Torrent", func(db *gorm.DB) *gorm.DB {
-					return db.Select([]string{"name", "hash", "total_file_size", "file_count"})
+					return db.Select([]string{"name", "hash", "total_file_size", "file_count", "private", "source", "has_web_seed"})
 				}).
 				Find(&out).Error
 			if err != nil {
@@ -79,6 +80,9 @@ func runSearchQuery(cc *cli.Context) (err error) {
 					" - ",
 					fmt.Sprintf("%.2f", match.Score),
 				)
+				for _, f := range match.Files {
+					println("   -", f.Path)
+				}
 			}
 		}
 		return
@@ -94,6 +98,9 @@ func runSearchIndex(cc *cli.Context) (err error) {
 }
 
 func searchIndex(ss *search.Service, ts *torrenti.Service) (err error) {
+	if !ss.Writable() {
+		return errors.New("search index is read only, set BLUGE_WRITE=true")
+	}
 	db := ts.DB
 	var out []*models.MetaFile
 	lastID := uint(0)
@@ -103,10 +110,7 @@ func searchIndex(ss *search.Service, ts *torrenti.Service) (err error) {
 		out = nil
 		err = db.
 			Model(models.MetaFile{}).Order("id").Where("id > ?", lastID).
-			Select([]string{"id", "filename", "content_hash", "torrent_hash", "creation_date"}).
-			Preload("Torrent", func(db *gorm.DB) *gorm.DB {
-				return db.Select([]string{"name", "hash", "total_file_size", "file_count"})
-			}).
+			Scopes(torrenti.TorrentDocumentScope).
 			Limit(1000).Find(&out).Error
 		if err != nil {
 			break
@@ -117,26 +121,66 @@ func searchIndex(ss *search.Service, ts *torrenti.Service) (err error) {
 		lastID = out[len(out)-1].ID
 		n += len(out)
 
-		docs := make([]*search.TorrentDocument, 0, len(out))
 		for _, v := range out {
 			if v.Torrent == nil {
 				log.Warn().Str("hash", v.TorrentHash).Msg("torrent not found")
-				continue
 			}
-			docs = append(docs, &search.TorrentDocument{
-				ID:              v.TorrentHash,
-				MetaFileName:    v.Filename,
-				TorrentFileName: v.Torrent.Name,
-				Size:            v.Torrent.TotalFileSize,
-				CreatedAt:       time.Unix(v.CreationDate, 0),
-			})
+		}
+		var docs []*search.TorrentDocument
+		if docs, err = torrenti.TorrentDocuments(context.Background(), db, out); err != nil {
+			return err
 		}
 		err = ss.IndexTorrent(context.Background(), docs)
 		if err != nil {
 			return err
 		}
 	}
-	log.Info().Int("count", n).Dur("duration", time.Now().Sub(start)).Msg("indexed")
+	if err != nil {
+		return
+	}
+	// purge deleted torrents indexed before
+	deleted, err := ts.ListTombstones(context.Background())
+	if err != nil {
+		return
+	}
+	for _, chunk := range lo.Chunk(deleted, 1000) {
+		if err = ss.DeleteTorrent(context.Background(), chunk); err != nil {
+			return
+		}
+	}
+	// changes during indexing
+	if _, err = ts.SyncSearch(context.Background(), ss, 0); err != nil {
+		return
+	}
+	if err = ss.MarkIndexed(); err != nil {
+		return
+	}
+	log.Info().Int("count", n).Int("deleted", len(deleted)).Dur("duration", time.Now().Sub(start)).Msg("indexed")
+	return
+}
+
+func serveSearchSync(sc *serve.Context, ss *search.Service) (err error) {
+	if !ss.Writable() {
+		log.Warn().Msg("search index is read only, indexed torrents are not searchable until search index")
+		return
+	}
+	if ss.Outdated() {
+		// rebuilt by sync in background, outbox survives restart
+		n, err := getTorrentIndexer().EnqueueSearchAll(sc.Context)
+		if err != nil {
+			return err
+		}
+		if err = ss.MarkIndexed(); err != nil {
+			return err
+		}
+		log.Info().Int("count", n).Msg("search index outdated, reindex all torrents")
+	}
+	ctx, cancel := context.WithCancel(sc.Context)
+	sc.G.Add(func() error {
+		return getTorrentIndexer().RunSearchSync(ctx, ss, _conf.Search.Interval)
+	}, func(err error) {
+		cancel()
+	})
 	return
 }
 
@@ -156,14 +200,18 @@ func fxApp(cc *cli.Context, opts ...fx.Option) (err error) {
 					if err != nil {
 						return
 					}
-					svc, err = torrenti.NewIndexer(torrenti.NewServiceOptions{DB: gdb})
+					lint, err := torrenti.ParseLintMode(conf.Torrent.Lint)
+					if err != nil {
+						return
+					}
+					svc, err = torrenti.NewIndexer(torrenti.NewServiceOptions{DB: gdb, Lint: lint})
 					return
 				},
-				func(svc *torrenti.Service) (svr torrentiv1.TorrentIndexServiceServer, err error) {
-					svr = &services.TorrentIndexerServer{Indexer: svc}
+				func(conf *Config, svc *torrenti.Service, ss *search.Service) (svr torrentiv1.TorrentIndexServiceServer, err error) {
+					svr = &services.TorrentIndexerServer{Indexer: svc, Search: ss, VerifyDirs: conf.Torrent.Verify, CreateDirs: conf.Torrent.Create}
 					serve.RegisterEndpoints(&serve.ServiceEndpoint{
 						Desc:            &torrentiv1.TorrentIndexService_ServiceDesc,
-						Impl:            &services.TorrentIndexerServer{Indexer: svc},
+						Impl:            svr,
 						RegisterGateway: torrentiv1.RegisterTorrentIndexServiceHandler,
 					})
 					return
@@ -185,6 +233,7 @@ func fxApp(cc *cli.Context, opts ...fx.Option) (err error) {
 			fx.Provide(func(conf *Config) (svc *search.Service, err error) {
 				svc, err = search.NewService(search.NewServiceOptions{
 					DataDir: filepath.Join(conf.DataDir, "search"),
+					Pinyin:  conf.Search.Pinyin,
 				})
 				if err == nil {
 				}
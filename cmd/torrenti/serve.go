@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -49,18 +50,21 @@ func runServer(cc *cli.Context) (err error) {
 	sc.Context = ctx
 
 	registerDebug(sc)
-	serve.RegisterEndpoints(&serve.ServiceEndpoint{
-		Desc:            &torrentiv1.TorrentIndexService_ServiceDesc,
-		Impl:            &services.TorrentIndexerServer{Indexer: getTorrentIndexer()},
-		RegisterGateway: torrentiv1.RegisterTorrentIndexServiceHandler,
-	})
-
 	ss, err := search.NewService(search.NewServiceOptions{
 		DataDir: filepath.Join(_conf.DataDir, "search"),
+		Write:   _conf.Search.Live,
+		Pinyin:  _conf.Search.Pinyin,
 	})
 	if err != nil {
 		return err
 	}
+	defer ss.Close()
+
+	serve.RegisterEndpoints(&serve.ServiceEndpoint{
+		Desc:            &torrentiv1.TorrentIndexService_ServiceDesc,
+		Impl:            &services.TorrentIndexerServer{Indexer: getTorrentIndexer(), Search: ss, VerifyDirs: _conf.Torrent.Verify, CreateDirs: _conf.Torrent.Create},
+		RegisterGateway: torrentiv1.RegisterTorrentIndexServiceHandler,
+	})
 
 	serve.RegisterEndpoints(&serve.ServiceEndpoint{
 		Desc: &webv1.WebService_ServiceDesc,
@@ -70,6 +74,15 @@ func runServer(cc *cli.Context) (err error) {
 		}),
 		RegisterGateway: webv1.RegisterWebServiceHandler,
 	})
+	serve.RegisterEndpoints(&serve.HTTPEndpoint{
+		EndpointDesc: serve.EndpointDesc{Name: "export torrents"},
+		Method:       http.MethodGet,
+		Path:         path.Join("/", _conf.GRPC.Gateway.Prefix, "torrents/export"),
+		Handler: web.NewExportHandler(web.NewExportHandlerOptions{
+			Indexer: getTorrentIndexer(),
+			Search:  ss,
+		}),
+	})
 
 	err = multierr.Combine(
 		serveHTTP(sc),
@@ -77,6 +90,9 @@ func runServer(cc *cli.Context) (err error) {
 		serveGRPC(sc),
 		serveGRPCGateway(sc),
 		serveScrape(sc),
+		serveWatch(sc),
+		serveDedup(sc),
+		serveSearchSync(sc, ss),
 	)
 
 	if err != nil {
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+)
+
+func verifyTorrent(ctx *cli.Context) (err error) {
+	if ctx.NArg() != 2 {
+		return errors.New("require hash and path")
+	}
+	format := ctx.String("format")
+	if format != "text" && format != "json" {
+		return errors.Errorf("invalid format: %q", format)
+	}
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+
+	r, err := getTorrentIndexer().VerifyTorrent(ctx.Context, ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return
+	}
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(r)
+	} else {
+		for _, f := range r.Files {
+			state := fmt.Sprintf("%.2f%%", f.Complete()*100)
+			switch {
+			case f.Missing:
+				state = "missing"
+			case f.RootMatch != nil && !*f.RootMatch && f.Complete() == 1:
+				state = "bad root"
+			}
+			fmt.Printf("%s\t%v/%v\t%s\n", state, f.GoodPieces, f.Pieces, f.Path)
+		}
+		fmt.Printf("complete %.2f%%, %v/%v pieces, %v bad pieces, %v missing files\n",
+			r.Complete()*100, r.GoodPieces, r.Pieces, len(r.BadPieces), len(r.Missing))
+	}
+	if err == nil && r.Complete() < 1 {
+		err = errors.New("incomplete")
+	}
+	return
+}
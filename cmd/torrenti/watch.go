@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+
+	"github.com/wenerme/torrenti/pkg/indexer"
+	"github.com/wenerme/torrenti/pkg/serve"
+)
+
+func serveWatch(sc *serve.Context) (err error) {
+	conf := _conf.Watch
+	if len(conf.Dirs) == 0 {
+		return
+	}
+	w, err := indexer.NewWatcher(indexer.NewWatcherOptions{
+		Dirs:     conf.Dirs,
+		Interval: conf.Interval,
+		Importer: &indexer.Importer{
+			Torrent:  getTorrentIndexer(),
+			Subtitle: getSubIndexer(),
+		},
+		DB: getTorrentIndexer().DB,
+	})
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(sc.Context)
+	sc.G.Add(func() error {
+		return w.Run(ctx)
+	}, func(err error) {
+		cancel()
+	})
+	return
+}
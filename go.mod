@@ -17,8 +17,10 @@ require (
 	github.com/gocolly/colly/v2 v2.0.0-00010101000000-000000000000
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.10.0
 	github.com/jackc/pgx/v4 v4.15.0
+	github.com/klauspost/compress v1.15.1
 	github.com/longbridgeapp/opencc v0.1.7
 	github.com/mitchellh/mapstructure v1.1.2
+	github.com/mozillazg/go-pinyin v0.20.0
 	github.com/multiformats/go-multihash v0.1.0
 	github.com/nwaples/rardecode/v2 v2.0.0-beta.2
 	github.com/oklog/run v1.1.0
@@ -92,7 +94,6 @@ require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.4 // indirect
 	github.com/kennygrant/sanitize v1.2.4 // indirect
-	github.com/klauspost/compress v1.15.1 // indirect
 	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
 	github.com/liuzl/cedar-go v0.0.0-20170805034717-80a9c64b256d // indirect
 	github.com/liuzl/da v0.0.0-20180704015230-14771aad5b1d // indirect
@@ -120,6 +120,7 @@ type Stat struct {
 	TorrentCount         int64 `protobuf:"varint,2,opt,name=torrent_count,json=torrentCount,proto3" json:"torrent_count,omitempty"`
 	TorrentFileCount     int64 `protobuf:"varint,3,opt,name=torrent_file_count,json=torrentFileCount,proto3" json:"torrent_file_count,omitempty"`
 	TorrentFileTotalSize int64 `protobuf:"varint,4,opt,name=torrent_file_total_size,json=torrentFileTotalSize,proto3" json:"torrent_file_total_size,omitempty"`
+	TombstoneCount       int64 `protobuf:"varint,6,opt,name=tombstone_count,json=tombstoneCount,proto3" json:"tombstone_count,omitempty"`
 }
 
 func (x *Stat) Reset() {
@@ -189,6 +190,13 @@ func (x *Stat) GetTorrentFileTotalSize() int64 {
 	return 0
 }
 
+func (x *Stat) GetTombstoneCount() int64 {
+	if x != nil {
+		return x.TombstoneCount
+	}
+	return 0
+}
+
 type IndexTorrentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -196,6 +204,8 @@ type IndexTorrentRequest struct {
 
 	File *common.File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
 	Hash string       `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// re-index and update derived columns if already exists
+	Force bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
 }
 
 func (x *IndexTorrentRequest) Reset() {
@@ -244,6 +254,13 @@ func (x *IndexTorrentRequest) GetHash() string {
 	return ""
 }
 
+func (x *IndexTorrentRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
 type IndexTorrentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -291,6 +308,566 @@ func (x *IndexTorrentResponse) GetHash() string {
 	return ""
 }
 
+type DeleteTorrentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// v1 or v2 info hash
+	Hashes []string `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	// content hash of meta file
+	ContentHashes []string `protobuf:"bytes,2,rep,name=content_hashes,json=contentHashes,proto3" json:"content_hashes,omitempty"`
+	// search query, limited to first 1000 matches
+	Query  string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *DeleteTorrentRequest) Reset() {
+	*x = DeleteTorrentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_torrenti_v1_index_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTorrentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTorrentRequest) ProtoMessage() {}
+
+func (x *DeleteTorrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_torrenti_v1_index_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTorrentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTorrentRequest) Descriptor() ([]byte, []int) {
+	return file_media_torrenti_v1_index_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteTorrentRequest) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *DeleteTorrentRequest) GetContentHashes() []string {
+	if x != nil {
+		return x.ContentHashes
+	}
+	return nil
+}
+
+func (x *DeleteTorrentRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *DeleteTorrentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteTorrentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes       []string `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	MetaCount    int64    `protobuf:"varint,2,opt,name=meta_count,json=metaCount,proto3" json:"meta_count,omitempty"`
+	TorrentCount int64    `protobuf:"varint,3,opt,name=torrent_count,json=torrentCount,proto3" json:"torrent_count,omitempty"`
+}
+
+func (x *DeleteTorrentResponse) Reset() {
+	*x = DeleteTorrentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_torrenti_v1_index_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTorrentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTorrentResponse) ProtoMessage() {}
+
+func (x *DeleteTorrentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_torrenti_v1_index_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTorrentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTorrentResponse) Descriptor() ([]byte, []int) {
+	return file_media_torrenti_v1_index_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteTorrentResponse) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *DeleteTorrentResponse) GetMetaCount() int64 {
+	if x != nil {
+		return x.MetaCount
+	}
+	return 0
+}
+
+func (x *DeleteTorrentResponse) GetTorrentCount() int64 {
+	if x != nil {
+		return x.TorrentCount
+	}
+	return 0
+}
+
+type CreateTorrentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// v1, v2 or hybrid
+	Version     string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	PieceLength int64  `protobuf:"varint,4,opt,name=piece_length,json=pieceLength,proto3" json:"piece_length,omitempty"`
+	// tracker tiers, trackers in tier are separated by comma
+	Trackers []string `protobuf:"bytes,5,rep,name=trackers,proto3" json:"trackers,omitempty"`
+	WebSeeds []string `protobuf:"bytes,6,rep,name=web_seeds,json=webSeeds,proto3" json:"web_seeds,omitempty"`
+	Private  bool     `protobuf:"varint,7,opt,name=private,proto3" json:"private,omitempty"`
+	Source   string   `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	Comment  string   `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	// index created torrent
+	Index bool `protobuf:"varint,10,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *CreateTorrentRequest) Reset() {
+	*x = CreateTorrentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_torrenti_v1_index_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTorrentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTorrentRequest) ProtoMessage() {}
+
+func (x *CreateTorrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_torrenti_v1_index_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTorrentRequest.ProtoReflect.Descriptor instead.
+func (*CreateTorrentRequest) Descriptor() ([]byte, []int) {
+	return file_media_torrenti_v1_index_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateTorrentRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateTorrentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTorrentRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CreateTorrentRequest) GetPieceLength() int64 {
+	if x != nil {
+		return x.PieceLength
+	}
+	return 0
+}
+
+func (x *CreateTorrentRequest) GetTrackers() []string {
+	if x != nil {
+		return x.Trackers
+	}
+	return nil
+}
+
+func (x *CreateTorrentRequest) GetWebSeeds() []string {
+	if x != nil {
+		return x.WebSeeds
+	}
+	return nil
+}
+
+func (x *CreateTorrentRequest) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *CreateTorrentRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CreateTorrentRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *CreateTorrentRequest) GetIndex() bool {
+	if x != nil {
+		return x.Index
+	}
+	return false
+}
+
+type CreateTorrentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data   []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Hash   string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	HashV2 string `protobuf:"bytes,3,opt,name=hash_v2,json=hashV2,proto3" json:"hash_v2,omitempty"`
+}
+
+func (x *CreateTorrentResponse) Reset() {
+	*x = CreateTorrentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_torrenti_v1_index_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTorrentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTorrentResponse) ProtoMessage() {}
+
+func (x *CreateTorrentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_torrenti_v1_index_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTorrentResponse.ProtoReflect.Descriptor instead.
+func (*CreateTorrentResponse) Descriptor() ([]byte, []int) {
+	return file_media_torrenti_v1_index_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateTorrentResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CreateTorrentResponse) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *CreateTorrentResponse) GetHashV2() string {
+	if x != nil {
+		return x.HashV2
+	}
+	return ""
+}
+
+type VerifyTorrentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// torrent content or dir contains it
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *VerifyTorrentRequest) Reset() {
+	*x = VerifyTorrentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_torrenti_v1_index_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyTorrentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTorrentRequest) ProtoMessage() {}
+
+func (x *VerifyTorrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_torrenti_v1_index_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTorrentRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTorrentRequest) Descriptor() ([]byte, []int) {
+	return file_media_torrenti_v1_index_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyTorrentRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *VerifyTorrentRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type VerifyTorrentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pieces     int32         `protobuf:"varint,1,opt,name=pieces,proto3" json:"pieces,omitempty"`
+	GoodPieces int32         `protobuf:"varint,2,opt,name=good_pieces,json=goodPieces,proto3" json:"good_pieces,omitempty"`
+	BadPieces  []int32       `protobuf:"varint,3,rep,packed,name=bad_pieces,json=badPieces,proto3" json:"bad_pieces,omitempty"`
+	Files      []*VerifyFile `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	Missing    []string      `protobuf:"bytes,5,rep,name=missing,proto3" json:"missing,omitempty"`
+	Complete   float64       `protobuf:"fixed64,6,opt,name=complete,proto3" json:"complete,omitempty"`
+}
+
+func (x *VerifyTorrentResponse) Reset() {
+	*x = VerifyTorrentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_torrenti_v1_index_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyTorrentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTorrentResponse) ProtoMessage() {}
+
+func (x *VerifyTorrentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_torrenti_v1_index_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTorrentResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTorrentResponse) Descriptor() ([]byte, []int) {
+	return file_media_torrenti_v1_index_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyTorrentResponse) GetPieces() int32 {
+	if x != nil {
+		return x.Pieces
+	}
+	return 0
+}
+
+func (x *VerifyTorrentResponse) GetGoodPieces() int32 {
+	if x != nil {
+		return x.GoodPieces
+	}
+	return 0
+}
+
+func (x *VerifyTorrentResponse) GetBadPieces() []int32 {
+	if x != nil {
+		return x.BadPieces
+	}
+	return nil
+}
+
+func (x *VerifyTorrentResponse) GetFiles() []*VerifyFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *VerifyTorrentResponse) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *VerifyTorrentResponse) GetComplete() float64 {
+	if x != nil {
+		return x.Complete
+	}
+	return 0
+}
+
+type VerifyFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path       string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size       int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	LocalSize  int64  `protobuf:"varint,3,opt,name=local_size,json=localSize,proto3" json:"local_size,omitempty"`
+	Missing    bool   `protobuf:"varint,4,opt,name=missing,proto3" json:"missing,omitempty"`
+	Pieces     int32  `protobuf:"varint,5,opt,name=pieces,proto3" json:"pieces,omitempty"`
+	GoodPieces int32  `protobuf:"varint,6,opt,name=good_pieces,json=goodPieces,proto3" json:"good_pieces,omitempty"`
+	RootMatch  *bool  `protobuf:"varint,7,opt,name=root_match,json=rootMatch,proto3,oneof" json:"root_match,omitempty"`
+}
+
+func (x *VerifyFile) Reset() {
+	*x = VerifyFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_torrenti_v1_index_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyFile) ProtoMessage() {}
+
+func (x *VerifyFile) ProtoReflect() protoreflect.Message {
+	mi := &file_media_torrenti_v1_index_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyFile.ProtoReflect.Descriptor instead.
+func (*VerifyFile) Descriptor() ([]byte, []int) {
+	return file_media_torrenti_v1_index_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *VerifyFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *VerifyFile) GetLocalSize() int64 {
+	if x != nil {
+		return x.LocalSize
+	}
+	return 0
+}
+
+func (x *VerifyFile) GetMissing() bool {
+	if x != nil {
+		return x.Missing
+	}
+	return false
+}
+
+func (x *VerifyFile) GetPieces() int32 {
+	if x != nil {
+		return x.Pieces
+	}
+	return 0
+}
+
+func (x *VerifyFile) GetGoodPieces() int32 {
+	if x != nil {
+		return x.GoodPieces
+	}
+	return 0
+}
+
+func (x *VerifyFile) GetRootMatch() bool {
+	if x != nil && x.RootMatch != nil {
+		return *x.RootMatch
+	}
+	return false
+}
+
 var File_media_torrenti_v1_index_service_proto protoreflect.FileDescriptor
 
 var file_media_torrenti_v1_index_service_proto_rawDesc = []byte{
@@ -309,7 +886,7 @@ var file_media_torrenti_v1_index_service_proto_rawDesc = []byte{
 	0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04,
 	0x73, 0x74, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x65, 0x64,
 	0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x52, 0x04, 0x73, 0x74, 0x61, 0x74, 0x22, 0xcc, 0x01, 0x0a, 0x04, 0x53, 0x74,
+	0x74, 0x61, 0x74, 0x52, 0x04, 0x73, 0x74, 0x61, 0x74, 0x22, 0xf5, 0x01, 0x0a, 0x04, 0x53, 0x74,
 	0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x43, 0x6f, 0x75, 0x6e,
 	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05,
@@ -322,44 +899,143 @@ var file_media_torrenti_v1_index_service_proto_rawDesc = []byte{
 	0x74, 0x12, 0x35, 0x0a, 0x17, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x69, 0x6c,
 	0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01,
 	0x28, 0x03, 0x52, 0x14, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x54,
-	0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x51, 0x0a, 0x13, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x26, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x2a, 0x0a, 0x14, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x32, 0xf3, 0x01, 0x0a, 0x13, 0x54, 0x6f, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x7b, 0x0a, 0x0c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12,
-	0x26, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e,
-	0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65,
-	0x6e, 0x74, 0x73, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x3a, 0x01, 0x2a, 0x12, 0x5f, 0x0a, 0x04,
-	0x53, 0x74, 0x61, 0x74, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f,
-	0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x42, 0xd3, 0x01,
-	0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x41, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x65, 0x6e, 0x65, 0x72, 0x6d, 0x65,
-	0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70,
-	0x69, 0x73, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x69, 0x2f, 0x76, 0x31, 0x3b, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x76, 0x31, 0xa2,
-	0x02, 0x03, 0x4d, 0x54, 0x58, 0xaa, 0x02, 0x11, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x54, 0x6f,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x4d, 0x65, 0x64, 0x69,
-	0x61, 0x5c, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d,
-	0x4d, 0x65, 0x64, 0x69, 0x61, 0x5c, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x5c, 0x56,
-	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13,
-	0x4d, 0x65, 0x64, 0x69, 0x61, 0x3a, 0x3a, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x3a,
-	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x6f, 0x6d, 0x62,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x74, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x67, 0x0a, 0x13, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x83, 0x01, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x73, 0x0a, 0x15,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x96, 0x02, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x69, 0x65, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x70, 0x69, 0x65, 0x63, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x1a, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x77,
+	0x65, 0x62, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x77, 0x65, 0x62, 0x53, 0x65, 0x65, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x58, 0x0a, 0x15, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x68,
+	0x61, 0x73, 0x68, 0x5f, 0x76, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61,
+	0x73, 0x68, 0x56, 0x32, 0x22, 0x3e, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x22, 0xda, 0x01, 0x0a, 0x15, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x6f, 0x6f, 0x64, 0x5f, 0x70,
+	0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x6f, 0x6f,
+	0x64, 0x50, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x64, 0x5f, 0x70,
+	0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x62, 0x61, 0x64,
+	0x50, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x22, 0xd9, 0x01, 0x0a, 0x0a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x6f, 0x6f,
+	0x64, 0x5f, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x67, 0x6f, 0x6f, 0x64, 0x50, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0a, 0x72, 0x6f,
+	0x6f, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
+	0x52, 0x09, 0x72, 0x6f, 0x6f, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x88, 0x01, 0x01, 0x42, 0x0d,
+	0x0a, 0x0b, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x32, 0xfe, 0x04,
+	0x0a, 0x13, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7b, 0x0a, 0x0c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f,
+	0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x3a,
+	0x01, 0x2a, 0x12, 0x5f, 0x0a, 0x04, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x73,
+	0x74, 0x61, 0x74, 0x12, 0x7f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e,
+	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22,
+	0x10, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x3a, 0x01, 0x2a, 0x12, 0x7f, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
+	0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15,
+	0x22, 0x10, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x86, 0x01, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e,
+	0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1c, 0x22, 0x17, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68,
+	0x61, 0x73, 0x68, 0x7d, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x3a, 0x01, 0x2a, 0x42, 0xd3,
+	0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x74, 0x6f, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x41, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x65, 0x6e, 0x65, 0x72, 0x6d,
+	0x65, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61,
+	0x70, 0x69, 0x73, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x76, 0x31,
+	0xa2, 0x02, 0x03, 0x4d, 0x54, 0x58, 0xaa, 0x02, 0x11, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x54,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x5c, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02,
+	0x1d, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x5c, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x5c,
+	0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
+	0x13, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x3a, 0x3a, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69,
+	0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -375,28 +1051,42 @@ func file_media_torrenti_v1_index_service_proto_rawDescGZIP() []byte {
 }
 
 var (
-	file_media_torrenti_v1_index_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+	file_media_torrenti_v1_index_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 	file_media_torrenti_v1_index_service_proto_goTypes  = []interface{}{
-		(*StatRequest)(nil),          // 0: media.torrenti.v1.StatRequest
-		(*StatResponse)(nil),         // 1: media.torrenti.v1.StatResponse
-		(*Stat)(nil),                 // 2: media.torrenti.v1.Stat
-		(*IndexTorrentRequest)(nil),  // 3: media.torrenti.v1.IndexTorrentRequest
-		(*IndexTorrentResponse)(nil), // 4: media.torrenti.v1.IndexTorrentResponse
-		(*common.File)(nil),          // 5: media.common.File
+		(*StatRequest)(nil),           // 0: media.torrenti.v1.StatRequest
+		(*StatResponse)(nil),          // 1: media.torrenti.v1.StatResponse
+		(*Stat)(nil),                  // 2: media.torrenti.v1.Stat
+		(*IndexTorrentRequest)(nil),   // 3: media.torrenti.v1.IndexTorrentRequest
+		(*IndexTorrentResponse)(nil),  // 4: media.torrenti.v1.IndexTorrentResponse
+		(*DeleteTorrentRequest)(nil),  // 5: media.torrenti.v1.DeleteTorrentRequest
+		(*DeleteTorrentResponse)(nil), // 6: media.torrenti.v1.DeleteTorrentResponse
+		(*CreateTorrentRequest)(nil),  // 7: media.torrenti.v1.CreateTorrentRequest
+		(*CreateTorrentResponse)(nil), // 8: media.torrenti.v1.CreateTorrentResponse
+		(*VerifyTorrentRequest)(nil),  // 9: media.torrenti.v1.VerifyTorrentRequest
+		(*VerifyTorrentResponse)(nil), // 10: media.torrenti.v1.VerifyTorrentResponse
+		(*VerifyFile)(nil),            // 11: media.torrenti.v1.VerifyFile
+		(*common.File)(nil),           // 12: media.common.File
 	}
 )
 var file_media_torrenti_v1_index_service_proto_depIdxs = []int32{
-	2, // 0: media.torrenti.v1.StatResponse.stat:type_name -> media.torrenti.v1.Stat
-	5, // 1: media.torrenti.v1.IndexTorrentRequest.file:type_name -> media.common.File
-	3, // 2: media.torrenti.v1.TorrentIndexService.IndexTorrent:input_type -> media.torrenti.v1.IndexTorrentRequest
-	0, // 3: media.torrenti.v1.TorrentIndexService.Stat:input_type -> media.torrenti.v1.StatRequest
-	4, // 4: media.torrenti.v1.TorrentIndexService.IndexTorrent:output_type -> media.torrenti.v1.IndexTorrentResponse
-	1, // 5: media.torrenti.v1.TorrentIndexService.Stat:output_type -> media.torrenti.v1.StatResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: media.torrenti.v1.StatResponse.stat:type_name -> media.torrenti.v1.Stat
+	12, // 1: media.torrenti.v1.IndexTorrentRequest.file:type_name -> media.common.File
+	11, // 2: media.torrenti.v1.VerifyTorrentResponse.files:type_name -> media.torrenti.v1.VerifyFile
+	3,  // 3: media.torrenti.v1.TorrentIndexService.IndexTorrent:input_type -> media.torrenti.v1.IndexTorrentRequest
+	0,  // 4: media.torrenti.v1.TorrentIndexService.Stat:input_type -> media.torrenti.v1.StatRequest
+	5,  // 5: media.torrenti.v1.TorrentIndexService.DeleteTorrent:input_type -> media.torrenti.v1.DeleteTorrentRequest
+	7,  // 6: media.torrenti.v1.TorrentIndexService.CreateTorrent:input_type -> media.torrenti.v1.CreateTorrentRequest
+	9,  // 7: media.torrenti.v1.TorrentIndexService.VerifyTorrent:input_type -> media.torrenti.v1.VerifyTorrentRequest
+	4,  // 8: media.torrenti.v1.TorrentIndexService.IndexTorrent:output_type -> media.torrenti.v1.IndexTorrentResponse
+	1,  // 9: media.torrenti.v1.TorrentIndexService.Stat:output_type -> media.torrenti.v1.StatResponse
+	6,  // 10: media.torrenti.v1.TorrentIndexService.DeleteTorrent:output_type -> media.torrenti.v1.DeleteTorrentResponse
+	8,  // 11: media.torrenti.v1.TorrentIndexService.CreateTorrent:output_type -> media.torrenti.v1.CreateTorrentResponse
+	10, // 12: media.torrenti.v1.TorrentIndexService.VerifyTorrent:output_type -> media.torrenti.v1.VerifyTorrentResponse
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_media_torrenti_v1_index_service_proto_init() }
@@ -465,14 +1155,99 @@ func file_media_torrenti_v1_index_service_proto_init() {
 				return nil
 			}
 		}
+		file_media_torrenti_v1_index_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTorrentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_torrenti_v1_index_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTorrentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_torrenti_v1_index_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTorrentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_torrenti_v1_index_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTorrentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_torrenti_v1_index_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyTorrentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_torrenti_v1_index_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyTorrentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_torrenti_v1_index_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_media_torrenti_v1_index_service_proto_msgTypes[11].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_media_torrenti_v1_index_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
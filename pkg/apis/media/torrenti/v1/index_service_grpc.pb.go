@@ -25,6 +25,12 @@ const _ = grpc.SupportPackageIsVersion7
 type TorrentIndexServiceClient interface {
 	IndexTorrent(ctx context.Context, in *IndexTorrentRequest, opts ...grpc.CallOption) (*IndexTorrentResponse, error)
 	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error)
+	// delete torrents and leave tombstone, deleted torrent will not be indexed again
+	DeleteTorrent(ctx context.Context, in *DeleteTorrentRequest, opts ...grpc.CallOption) (*DeleteTorrentResponse, error)
+	// create torrent from local content, path must under configured create dirs
+	CreateTorrent(ctx context.Context, in *CreateTorrentRequest, opts ...grpc.CallOption) (*CreateTorrentResponse, error)
+	// verify local data of torrent, path must under configured verify dirs
+	VerifyTorrent(ctx context.Context, in *VerifyTorrentRequest, opts ...grpc.CallOption) (*VerifyTorrentResponse, error)
 }
 
 type torrentIndexServiceClient struct {
@@ -53,12 +59,45 @@ func (c *torrentIndexServiceClient) Stat(ctx context.Context, in *StatRequest, o
 	return out, nil
 }
 
+func (c *torrentIndexServiceClient) DeleteTorrent(ctx context.Context, in *DeleteTorrentRequest, opts ...grpc.CallOption) (*DeleteTorrentResponse, error) {
+	out := new(DeleteTorrentResponse)
+	err := c.cc.Invoke(ctx, "/media.torrenti.v1.TorrentIndexService/DeleteTorrent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *torrentIndexServiceClient) CreateTorrent(ctx context.Context, in *CreateTorrentRequest, opts ...grpc.CallOption) (*CreateTorrentResponse, error) {
+	out := new(CreateTorrentResponse)
+	err := c.cc.Invoke(ctx, "/media.torrenti.v1.TorrentIndexService/CreateTorrent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *torrentIndexServiceClient) VerifyTorrent(ctx context.Context, in *VerifyTorrentRequest, opts ...grpc.CallOption) (*VerifyTorrentResponse, error) {
+	out := new(VerifyTorrentResponse)
+	err := c.cc.Invoke(ctx, "/media.torrenti.v1.TorrentIndexService/VerifyTorrent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TorrentIndexServiceServer is the server API for TorrentIndexService service.
 // All implementations must embed UnimplementedTorrentIndexServiceServer
 // for forward compatibility
 type TorrentIndexServiceServer interface {
 	IndexTorrent(context.Context, *IndexTorrentRequest) (*IndexTorrentResponse, error)
 	Stat(context.Context, *StatRequest) (*StatResponse, error)
+	// delete torrents and leave tombstone, deleted torrent will not be indexed again
+	DeleteTorrent(context.Context, *DeleteTorrentRequest) (*DeleteTorrentResponse, error)
+	// create torrent from local content, path must under configured create dirs
+	CreateTorrent(context.Context, *CreateTorrentRequest) (*CreateTorrentResponse, error)
+	// verify local data of torrent, path must under configured verify dirs
+	VerifyTorrent(context.Context, *VerifyTorrentRequest) (*VerifyTorrentResponse, error)
 	mustEmbedUnimplementedTorrentIndexServiceServer()
 }
 
@@ -72,6 +111,18 @@ func (UnimplementedTorrentIndexServiceServer) IndexTorrent(context.Context, *Ind
 func (UnimplementedTorrentIndexServiceServer) Stat(context.Context, *StatRequest) (*StatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
 }
+
+func (UnimplementedTorrentIndexServiceServer) DeleteTorrent(context.Context, *DeleteTorrentRequest) (*DeleteTorrentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTorrent not implemented")
+}
+
+func (UnimplementedTorrentIndexServiceServer) CreateTorrent(context.Context, *CreateTorrentRequest) (*CreateTorrentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTorrent not implemented")
+}
+
+func (UnimplementedTorrentIndexServiceServer) VerifyTorrent(context.Context, *VerifyTorrentRequest) (*VerifyTorrentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTorrent not implemented")
+}
 func (UnimplementedTorrentIndexServiceServer) mustEmbedUnimplementedTorrentIndexServiceServer() {}
 
 // UnsafeTorrentIndexServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -121,6 +172,60 @@ func _TorrentIndexService_Stat_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TorrentIndexService_DeleteTorrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTorrentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TorrentIndexServiceServer).DeleteTorrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.torrenti.v1.TorrentIndexService/DeleteTorrent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TorrentIndexServiceServer).DeleteTorrent(ctx, req.(*DeleteTorrentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TorrentIndexService_CreateTorrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTorrentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TorrentIndexServiceServer).CreateTorrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.torrenti.v1.TorrentIndexService/CreateTorrent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TorrentIndexServiceServer).CreateTorrent(ctx, req.(*CreateTorrentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TorrentIndexService_VerifyTorrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTorrentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TorrentIndexServiceServer).VerifyTorrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.torrenti.v1.TorrentIndexService/VerifyTorrent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TorrentIndexServiceServer).VerifyTorrent(ctx, req.(*VerifyTorrentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TorrentIndexService_ServiceDesc is the grpc.ServiceDesc for TorrentIndexService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -136,6 +241,18 @@ var TorrentIndexService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Stat",
 			Handler:    _TorrentIndexService_Stat_Handler,
 		},
+		{
+			MethodName: "DeleteTorrent",
+			Handler:    _TorrentIndexService_DeleteTorrent_Handler,
+		},
+		{
+			MethodName: "CreateTorrent",
+			Handler:    _TorrentIndexService_CreateTorrent_Handler,
+		},
+		{
+			MethodName: "VerifyTorrent",
+			Handler:    _TorrentIndexService_VerifyTorrent_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "media/torrenti/v1/index_service.proto",
@@ -228,9 +228,18 @@ type SearchTorrentRefRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// terms are required, supports "phrase", -exclude, OR, (group),
+	// name:, file:, path:, site:, ext:mkv, size:>4GB, size:1GB..10GB, date:2021..2022, files:>100
 	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
 	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset int32  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// only torrents has http web seed
+	HasWebSeed bool `protobuf:"varint,5,opt,name=has_web_seed,json=hasWebSeed,proto3" json:"has_web_seed,omitempty"`
+	// keep only the first torrent of duplicate group in page
+	CollapseDuplicates bool `protobuf:"varint,6,opt,name=collapse_duplicates,json=collapseDuplicates,proto3" json:"collapse_duplicates,omitempty"`
+	// selected facet values, e.g. ext:mkv, values of same facet match any
+	Filters []string `protobuf:"bytes,7,rep,name=filters,proto3" json:"filters,omitempty"`
 }
 
 func (x *SearchTorrentRefRequest) Reset() {
@@ -286,6 +295,34 @@ func (x *SearchTorrentRefRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *SearchTorrentRefRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SearchTorrentRefRequest) GetHasWebSeed() bool {
+	if x != nil {
+		return x.HasWebSeed
+	}
+	return false
+}
+
+func (x *SearchTorrentRefRequest) GetCollapseDuplicates() bool {
+	if x != nil {
+		return x.CollapseDuplicates
+	}
+	return false
+}
+
+func (x *SearchTorrentRefRequest) GetFilters() []string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
 type SearchTorrentRefResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -294,6 +331,7 @@ type SearchTorrentRefResponse struct {
 	Items    []*SearchTorrentRef `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	Total    int32               `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	Duration int32               `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Facets   []*SearchFacet      `protobuf:"bytes,4,rep,name=facets,proto3" json:"facets,omitempty"`
 }
 
 func (x *SearchTorrentRefResponse) Reset() {
@@ -349,6 +387,125 @@ func (x *SearchTorrentRefResponse) GetDuration() int32 {
 	return 0
 }
 
+func (x *SearchTorrentRefResponse) GetFacets() []*SearchFacet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+type SearchFacet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ext, size, date or site
+	Name   string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values []*SearchFacetValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *SearchFacet) Reset() {
+	*x = SearchFacet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacet) ProtoMessage() {}
+
+func (x *SearchFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacet.ProtoReflect.Descriptor instead.
+func (*SearchFacet) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchFacet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchFacet) GetValues() []*SearchFacetValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type SearchFacetValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// filter is name:value
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SearchFacetValue) Reset() {
+	*x = SearchFacetValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchFacetValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacetValue) ProtoMessage() {}
+
+func (x *SearchFacetValue) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacetValue.ProtoReflect.Descriptor instead.
+func (*SearchFacetValue) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchFacetValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SearchFacetValue) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type SearchTorrentRef struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -357,12 +514,16 @@ type SearchTorrentRef struct {
 	Item                 *TorrentRef `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	HighlightFileName    string      `protobuf:"bytes,2,opt,name=highlight_file_name,json=highlightFileName,proto3" json:"highlight_file_name,omitempty"`
 	HighlightTorrentName string      `protobuf:"bytes,3,opt,name=highlight_torrent_name,json=highlightTorrentName,proto3" json:"highlight_torrent_name,omitempty"`
+	// number of collapsed duplicates
+	Duplicates int32 `protobuf:"varint,4,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	// matched file paths in torrent
+	Files []*SearchFileMatch `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
 }
 
 func (x *SearchTorrentRef) Reset() {
 	*x = SearchTorrentRef{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_media_web_v1_web_services_proto_msgTypes[6]
+		mi := &file_media_web_v1_web_services_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -375,7 +536,7 @@ func (x *SearchTorrentRef) String() string {
 func (*SearchTorrentRef) ProtoMessage() {}
 
 func (x *SearchTorrentRef) ProtoReflect() protoreflect.Message {
-	mi := &file_media_web_v1_web_services_proto_msgTypes[6]
+	mi := &file_media_web_v1_web_services_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +549,7 @@ func (x *SearchTorrentRef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchTorrentRef.ProtoReflect.Descriptor instead.
 func (*SearchTorrentRef) Descriptor() ([]byte, []int) {
-	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{6}
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SearchTorrentRef) GetItem() *TorrentRef {
@@ -412,6 +573,76 @@ func (x *SearchTorrentRef) GetHighlightTorrentName() string {
 	return ""
 }
 
+func (x *SearchTorrentRef) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *SearchTorrentRef) GetFiles() []*SearchFileMatch {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type SearchFileMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// html with matched terms in mark
+	Highlight string `protobuf:"bytes,2,opt,name=highlight,proto3" json:"highlight,omitempty"`
+}
+
+func (x *SearchFileMatch) Reset() {
+	*x = SearchFileMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchFileMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFileMatch) ProtoMessage() {}
+
+func (x *SearchFileMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFileMatch.ProtoReflect.Descriptor instead.
+func (*SearchFileMatch) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchFileMatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SearchFileMatch) GetHighlight() string {
+	if x != nil {
+		return x.Highlight
+	}
+	return ""
+}
+
 type GetTorrentRefRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -423,7 +654,7 @@ type GetTorrentRefRequest struct {
 func (x *GetTorrentRefRequest) Reset() {
 	*x = GetTorrentRefRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_media_web_v1_web_services_proto_msgTypes[7]
+		mi := &file_media_web_v1_web_services_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -436,7 +667,7 @@ func (x *GetTorrentRefRequest) String() string {
 func (*GetTorrentRefRequest) ProtoMessage() {}
 
 func (x *GetTorrentRefRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_web_v1_web_services_proto_msgTypes[7]
+	mi := &file_media_web_v1_web_services_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +680,7 @@ func (x *GetTorrentRefRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTorrentRefRequest.ProtoReflect.Descriptor instead.
 func (*GetTorrentRefRequest) Descriptor() ([]byte, []int) {
-	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{7}
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetTorrentRefRequest) GetHash() string {
@@ -470,7 +701,7 @@ type GetTorrentRefResponse struct {
 func (x *GetTorrentRefResponse) Reset() {
 	*x = GetTorrentRefResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_media_web_v1_web_services_proto_msgTypes[8]
+		mi := &file_media_web_v1_web_services_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -483,7 +714,7 @@ func (x *GetTorrentRefResponse) String() string {
 func (*GetTorrentRefResponse) ProtoMessage() {}
 
 func (x *GetTorrentRefResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_media_web_v1_web_services_proto_msgTypes[8]
+	mi := &file_media_web_v1_web_services_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,7 +727,7 @@ func (x *GetTorrentRefResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTorrentRefResponse.ProtoReflect.Descriptor instead.
 func (*GetTorrentRefResponse) Descriptor() ([]byte, []int) {
-	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{8}
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetTorrentRefResponse) GetItem() *Torrent {
@@ -524,7 +755,7 @@ type TorrentRef struct {
 func (x *TorrentRef) Reset() {
 	*x = TorrentRef{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_media_web_v1_web_services_proto_msgTypes[9]
+		mi := &file_media_web_v1_web_services_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -537,7 +768,7 @@ func (x *TorrentRef) String() string {
 func (*TorrentRef) ProtoMessage() {}
 
 func (x *TorrentRef) ProtoReflect() protoreflect.Message {
-	mi := &file_media_web_v1_web_services_proto_msgTypes[9]
+	mi := &file_media_web_v1_web_services_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,7 +781,7 @@ func (x *TorrentRef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TorrentRef.ProtoReflect.Descriptor instead.
 func (*TorrentRef) Descriptor() ([]byte, []int) {
-	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{9}
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *TorrentRef) GetFileName() string {
@@ -614,19 +845,26 @@ type Torrent struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FileName  string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	Hash      string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
-	Magnet    string `protobuf:"bytes,3,opt,name=magnet,proto3" json:"magnet,omitempty"`
-	FileSize  int64  `protobuf:"varint,4,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
-	FileCount int32  `protobuf:"varint,5,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
-	Ext       string `protobuf:"bytes,6,opt,name=ext,proto3" json:"ext,omitempty"`
-	IsDir     bool   `protobuf:"varint,7,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	FileName  string   `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Hash      string   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Magnet    string   `protobuf:"bytes,3,opt,name=magnet,proto3" json:"magnet,omitempty"`
+	FileSize  int64    `protobuf:"varint,4,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	FileCount int32    `protobuf:"varint,5,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	Ext       string   `protobuf:"bytes,6,opt,name=ext,proto3" json:"ext,omitempty"`
+	IsDir     bool     `protobuf:"varint,7,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	HashV2    string   `protobuf:"bytes,8,opt,name=hash_v2,json=hashV2,proto3" json:"hash_v2,omitempty"`
+	Private   bool     `protobuf:"varint,9,opt,name=private,proto3" json:"private,omitempty"`
+	Source    string   `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`
+	WebSeeds  []string `protobuf:"bytes,11,rep,name=web_seeds,json=webSeeds,proto3" json:"web_seeds,omitempty"`
+	Nodes     []string `protobuf:"bytes,12,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// charset of legacy encoded name, empty if utf-8
+	Charset string `protobuf:"bytes,13,opt,name=charset,proto3" json:"charset,omitempty"`
 }
 
 func (x *Torrent) Reset() {
 	*x = Torrent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_media_web_v1_web_services_proto_msgTypes[10]
+		mi := &file_media_web_v1_web_services_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -639,7 +877,7 @@ func (x *Torrent) String() string {
 func (*Torrent) ProtoMessage() {}
 
 func (x *Torrent) ProtoReflect() protoreflect.Message {
-	mi := &file_media_web_v1_web_services_proto_msgTypes[10]
+	mi := &file_media_web_v1_web_services_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -652,7 +890,7 @@ func (x *Torrent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Torrent.ProtoReflect.Descriptor instead.
 func (*Torrent) Descriptor() ([]byte, []int) {
-	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{10}
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Torrent) GetFileName() string {
@@ -704,32 +942,73 @@ func (x *Torrent) GetIsDir() bool {
 	return false
 }
 
-type ListTorrentRefRequest struct {
+func (x *Torrent) GetHashV2() string {
+	if x != nil {
+		return x.HashV2
+	}
+	return ""
+}
+
+func (x *Torrent) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *Torrent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Torrent) GetWebSeeds() []string {
+	if x != nil {
+		return x.WebSeeds
+	}
+	return nil
+}
+
+func (x *Torrent) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Torrent) GetCharset() string {
+	if x != nil {
+		return x.Charset
+	}
+	return ""
+}
+
+type ListDuplicateTorrentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
-	Page   int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (x *ListTorrentRefRequest) Reset() {
-	*x = ListTorrentRefRequest{}
+func (x *ListDuplicateTorrentRequest) Reset() {
+	*x = ListDuplicateTorrentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_media_web_v1_web_services_proto_msgTypes[11]
+		mi := &file_media_web_v1_web_services_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListTorrentRefRequest) String() string {
+func (x *ListDuplicateTorrentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTorrentRefRequest) ProtoMessage() {}
+func (*ListDuplicateTorrentRequest) ProtoMessage() {}
 
-func (x *ListTorrentRefRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_web_v1_web_services_proto_msgTypes[11]
+func (x *ListDuplicateTorrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -740,52 +1019,45 @@ func (x *ListTorrentRefRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTorrentRefRequest.ProtoReflect.Descriptor instead.
-func (*ListTorrentRefRequest) Descriptor() ([]byte, []int) {
-	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListDuplicateTorrentRequest.ProtoReflect.Descriptor instead.
+func (*ListDuplicateTorrentRequest) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListTorrentRefRequest) GetSearch() string {
+func (x *ListDuplicateTorrentRequest) GetHash() string {
 	if x != nil {
-		return x.Search
+		return x.Hash
 	}
 	return ""
 }
 
-func (x *ListTorrentRefRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-type ListTorrentRefResponse struct {
+type ListDuplicateTorrentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Items       []*TorrentRef `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	HasNext     bool          `protobuf:"varint,2,opt,name=hasNext,proto3" json:"hasNext,omitempty"`
-	HasPrevious bool          `protobuf:"varint,3,opt,name=hasPrevious,proto3" json:"hasPrevious,omitempty"`
+	// hash of first indexed torrent in group, empty if no duplicate
+	GroupHash string     `protobuf:"bytes,1,opt,name=group_hash,json=groupHash,proto3" json:"group_hash,omitempty"`
+	Items     []*Torrent `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 }
 
-func (x *ListTorrentRefResponse) Reset() {
-	*x = ListTorrentRefResponse{}
+func (x *ListDuplicateTorrentResponse) Reset() {
+	*x = ListDuplicateTorrentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_media_web_v1_web_services_proto_msgTypes[12]
+		mi := &file_media_web_v1_web_services_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListTorrentRefResponse) String() string {
+func (x *ListDuplicateTorrentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTorrentRefResponse) ProtoMessage() {}
+func (*ListDuplicateTorrentResponse) ProtoMessage() {}
 
-func (x *ListTorrentRefResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_media_web_v1_web_services_proto_msgTypes[12]
+func (x *ListDuplicateTorrentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -796,28 +1068,786 @@ func (x *ListTorrentRefResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTorrentRefResponse.ProtoReflect.Descriptor instead.
-func (*ListTorrentRefResponse) Descriptor() ([]byte, []int) {
-	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListDuplicateTorrentResponse.ProtoReflect.Descriptor instead.
+func (*ListDuplicateTorrentResponse) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListTorrentRefResponse) GetItems() []*TorrentRef {
+func (x *ListDuplicateTorrentResponse) GetGroupHash() string {
 	if x != nil {
-		return x.Items
+		return x.GroupHash
 	}
-	return nil
+	return ""
+}
+
+func (x *ListDuplicateTorrentResponse) GetItems() []*Torrent {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ListFileRootTorrentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hex encoded v2 pieces root
+	PiecesRoot string `protobuf:"bytes,1,opt,name=pieces_root,json=piecesRoot,proto3" json:"pieces_root,omitempty"`
+	Page       int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListFileRootTorrentRequest) Reset() {
+	*x = ListFileRootTorrentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFileRootTorrentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileRootTorrentRequest) ProtoMessage() {}
+
+func (x *ListFileRootTorrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileRootTorrentRequest.ProtoReflect.Descriptor instead.
+func (*ListFileRootTorrentRequest) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListFileRootTorrentRequest) GetPiecesRoot() string {
+	if x != nil {
+		return x.PiecesRoot
+	}
+	return ""
+}
+
+func (x *ListFileRootTorrentRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListFileRootTorrentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items   []*FileRootTorrent `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	HasNext bool               `protobuf:"varint,2,opt,name=hasNext,proto3" json:"hasNext,omitempty"`
+}
+
+func (x *ListFileRootTorrentResponse) Reset() {
+	*x = ListFileRootTorrentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFileRootTorrentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileRootTorrentResponse) ProtoMessage() {}
+
+func (x *ListFileRootTorrentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileRootTorrentResponse.ProtoReflect.Descriptor instead.
+func (*ListFileRootTorrentResponse) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListFileRootTorrentResponse) GetItems() []*FileRootTorrent {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListFileRootTorrentResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type FileRootTorrent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Torrent *Torrent     `protobuf:"bytes,1,opt,name=torrent,proto3" json:"torrent,omitempty"`
+	File    *TorrentFile `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+}
+
+func (x *FileRootTorrent) Reset() {
+	*x = FileRootTorrent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileRootTorrent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRootTorrent) ProtoMessage() {}
+
+func (x *FileRootTorrent) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRootTorrent.ProtoReflect.Descriptor instead.
+func (*FileRootTorrent) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FileRootTorrent) GetTorrent() *Torrent {
+	if x != nil {
+		return x.Torrent
+	}
+	return nil
+}
+
+func (x *FileRootTorrent) GetFile() *TorrentFile {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type ListTorrentRefRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	Page   int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListTorrentRefRequest) Reset() {
+	*x = ListTorrentRefRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTorrentRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTorrentRefRequest) ProtoMessage() {}
+
+func (x *ListTorrentRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTorrentRefRequest.ProtoReflect.Descriptor instead.
+func (*ListTorrentRefRequest) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListTorrentRefRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListTorrentRefRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListTorrentRefResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items       []*TorrentRef `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	HasNext     bool          `protobuf:"varint,2,opt,name=hasNext,proto3" json:"hasNext,omitempty"`
+	HasPrevious bool          `protobuf:"varint,3,opt,name=hasPrevious,proto3" json:"hasPrevious,omitempty"`
+}
+
+func (x *ListTorrentRefResponse) Reset() {
+	*x = ListTorrentRefResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTorrentRefResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTorrentRefResponse) ProtoMessage() {}
+
+func (x *ListTorrentRefResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTorrentRefResponse.ProtoReflect.Descriptor instead.
+func (*ListTorrentRefResponse) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTorrentRefResponse) GetItems() []*TorrentRef {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTorrentRefResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *ListTorrentRefResponse) GetHasPrevious() bool {
+	if x != nil {
+		return x.HasPrevious
+	}
+	return false
+}
+
+type Tracker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url          string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Protocol     string `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	TorrentCount int64  `protobuf:"varint,3,opt,name=torrent_count,json=torrentCount,proto3" json:"torrent_count,omitempty"`
+}
+
+func (x *Tracker) Reset() {
+	*x = Tracker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tracker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tracker) ProtoMessage() {}
+
+func (x *Tracker) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracker.ProtoReflect.Descriptor instead.
+func (*Tracker) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Tracker) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Tracker) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *Tracker) GetTorrentCount() int64 {
+	if x != nil {
+		return x.TorrentCount
+	}
+	return 0
+}
+
+type TorrentFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// BEP 47 attributes, x=executable h=hidden l=symlink
+	Attr        string `protobuf:"bytes,3,opt,name=attr,proto3" json:"attr,omitempty"`
+	SymlinkPath string `protobuf:"bytes,4,opt,name=symlink_path,json=symlinkPath,proto3" json:"symlink_path,omitempty"`
+	Sha1        string `protobuf:"bytes,5,opt,name=sha1,proto3" json:"sha1,omitempty"`
+	PiecesRoot  string `protobuf:"bytes,6,opt,name=pieces_root,json=piecesRoot,proto3" json:"pieces_root,omitempty"`
+}
+
+func (x *TorrentFile) Reset() {
+	*x = TorrentFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TorrentFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TorrentFile) ProtoMessage() {}
+
+func (x *TorrentFile) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TorrentFile.ProtoReflect.Descriptor instead.
+func (*TorrentFile) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TorrentFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TorrentFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *TorrentFile) GetAttr() string {
+	if x != nil {
+		return x.Attr
+	}
+	return ""
+}
+
+func (x *TorrentFile) GetSymlinkPath() string {
+	if x != nil {
+		return x.SymlinkPath
+	}
+	return ""
+}
+
+func (x *TorrentFile) GetSha1() string {
+	if x != nil {
+		return x.Sha1
+	}
+	return ""
+}
+
+func (x *TorrentFile) GetPiecesRoot() string {
+	if x != nil {
+		return x.PiecesRoot
+	}
+	return ""
+}
+
+type ListTorrentFileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Page int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListTorrentFileRequest) Reset() {
+	*x = ListTorrentFileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTorrentFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTorrentFileRequest) ProtoMessage() {}
+
+func (x *ListTorrentFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTorrentFileRequest.ProtoReflect.Descriptor instead.
+func (*ListTorrentFileRequest) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTorrentFileRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *ListTorrentFileRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListTorrentFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items   []*TorrentFile `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	HasNext bool           `protobuf:"varint,2,opt,name=hasNext,proto3" json:"hasNext,omitempty"`
+}
+
+func (x *ListTorrentFileResponse) Reset() {
+	*x = ListTorrentFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTorrentFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTorrentFileResponse) ProtoMessage() {}
+
+func (x *ListTorrentFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTorrentFileResponse.ProtoReflect.Descriptor instead.
+func (*ListTorrentFileResponse) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTorrentFileResponse) GetItems() []*TorrentFile {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTorrentFileResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type ListTrackerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Protocol string `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Page     int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListTrackerRequest) Reset() {
+	*x = ListTrackerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackerRequest) ProtoMessage() {}
+
+func (x *ListTrackerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackerRequest.ProtoReflect.Descriptor instead.
+func (*ListTrackerRequest) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTrackerRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ListTrackerRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListTrackerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items   []*Tracker `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	HasNext bool       `protobuf:"varint,2,opt,name=hasNext,proto3" json:"hasNext,omitempty"`
+}
+
+func (x *ListTrackerResponse) Reset() {
+	*x = ListTrackerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackerResponse) ProtoMessage() {}
+
+func (x *ListTrackerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackerResponse.ProtoReflect.Descriptor instead.
+func (*ListTrackerResponse) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTrackerResponse) GetItems() []*Tracker {
+	if x != nil {
+		return x.Items
+	}
+	return nil
 }
 
-func (x *ListTorrentRefResponse) GetHasNext() bool {
+func (x *ListTrackerResponse) GetHasNext() bool {
 	if x != nil {
 		return x.HasNext
 	}
 	return false
 }
 
-func (x *ListTorrentRefResponse) GetHasPrevious() bool {
+type ListTrackerTorrentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url  string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Page int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListTrackerTorrentRequest) Reset() {
+	*x = ListTrackerTorrentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackerTorrentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackerTorrentRequest) ProtoMessage() {}
+
+func (x *ListTrackerTorrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackerTorrentRequest.ProtoReflect.Descriptor instead.
+func (*ListTrackerTorrentRequest) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTrackerTorrentRequest) GetUrl() string {
 	if x != nil {
-		return x.HasPrevious
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ListTrackerTorrentRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListTrackerTorrentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items   []*Torrent `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	HasNext bool       `protobuf:"varint,2,opt,name=hasNext,proto3" json:"hasNext,omitempty"`
+}
+
+func (x *ListTrackerTorrentResponse) Reset() {
+	*x = ListTrackerTorrentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_web_v1_web_services_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackerTorrentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackerTorrentResponse) ProtoMessage() {}
+
+func (x *ListTrackerTorrentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_web_v1_web_services_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackerTorrentResponse.ProtoReflect.Descriptor instead.
+func (*ListTrackerTorrentResponse) Descriptor() ([]byte, []int) {
+	return file_media_web_v1_web_services_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListTrackerTorrentResponse) GetItems() []*Torrent {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTrackerTorrentResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
 	}
 	return false
 }
@@ -855,134 +1885,293 @@ var file_media_web_v1_web_services_proto_rawDesc = []byte{
 	0x4d, 0x65, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04,
 	0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
-	0x75, 0x63, 0x74, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x17, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x82, 0x01, 0x0a, 0x18, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77,
-	0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
-	0xa6, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x66, 0x12, 0x2c, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76,
-	0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x04, 0x69, 0x74,
-	0x65, 0x6d, 0x12, 0x2e, 0x0a, 0x13, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f,
-	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x11, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f,
-	0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x14, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x54, 0x6f, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54,
+	0x75, 0x63, 0x74, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x22, 0xe4, 0x01, 0x0a, 0x17, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x68, 0x61, 0x73, 0x5f, 0x77, 0x65, 0x62, 0x5f, 0x73,
+	0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x57, 0x65,
+	0x62, 0x53, 0x65, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73,
+	0x65, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x12, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x44, 0x75, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x22, 0xb5, 0x01, 0x0a, 0x18, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x61, 0x63, 0x65, 0x74,
+	0x52, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x22, 0x59, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x46, 0x61, 0x63, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65,
+	0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x46, 0x61, 0x63, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x22, 0x3e, 0x0a, 0x10, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x61, 0x63,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xfb, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x2c, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77,
+	0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66,
+	0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12, 0x2e, 0x0a, 0x13, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x46, 0x69,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x05,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65,
+	0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x22, 0x43, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x65, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x69, 0x67, 0x68,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x68, 0x69, 0x67,
+	0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x22, 0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x22, 0x42, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x69,
+	0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0xb9, 0x02, 0x0a, 0x0a, 0x54, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x42, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x34, 0x0a, 0x07, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x07, 0x74, 0x6f, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x74, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x22, 0xcf, 0x02, 0x0a, 0x07, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x61, 0x67, 0x6e, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x61, 0x67, 0x6e, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x65, 0x78, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x64, 0x69, 0x72, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x44, 0x69, 0x72, 0x12, 0x17, 0x0a, 0x07,
+	0x68, 0x61, 0x73, 0x68, 0x5f, 0x76, 0x32, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68,
+	0x61, 0x73, 0x68, 0x56, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x65, 0x62, 0x5f, 0x73,
+	0x65, 0x65, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x65, 0x62, 0x53,
+	0x65, 0x65, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x72, 0x73, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x72, 0x73, 0x65, 0x74, 0x22, 0x31, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x75, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x6a, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x22, 0x51, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x6f, 0x6f, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x52, 0x6f,
+	0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x22, 0x6c, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x54, 0x6f, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61,
+	0x73, 0x4e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73,
+	0x4e, 0x65, 0x78, 0x74, 0x22, 0x71, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x74, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52,
+	0x07, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77,
+	0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x43, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x54,
 	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x68, 0x61, 0x73, 0x68, 0x22, 0x42, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a,
-	0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65,
-	0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65,
-	0x6e, 0x74, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0xb9, 0x02, 0x0a, 0x0a, 0x54, 0x6f, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73,
-	0x68, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x42, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
-	0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
-	0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61,
-	0x73, 0x68, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e,
-	0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x07, 0x74, 0x6f,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x74, 0x6f, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x22, 0xb7, 0x01, 0x0a, 0x07, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x67, 0x6e, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x6d, 0x61, 0x67, 0x6e, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c,
-	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69,
-	0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x65, 0x78, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x64, 0x69,
-	0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x44, 0x69, 0x72, 0x22, 0x43,
-	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x22, 0x84, 0x01, 0x0a,
+	0x16, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77,
+	0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65,
+	0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x61, 0x73, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x50, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x22, 0x5c, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0xa1, 0x01, 0x0a, 0x0b, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x74,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x74, 0x74, 0x72, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x79, 0x6d, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x79, 0x6d, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x68, 0x61, 0x31, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x68, 0x61, 0x31, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x5f, 0x72,
+	0x6f, 0x6f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x69, 0x65, 0x63, 0x65,
+	0x73, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0x40, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x22, 0x64, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x22, 0x44, 0x0a,
+	0x12, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
 	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
-	0x61, 0x67, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e,
-	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x18,
-	0x0a, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x61, 0x73, 0x50,
-	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68,
-	0x61, 0x73, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x32, 0xf9, 0x04, 0x0a, 0x0a, 0x57,
-	0x65, 0x62, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6e, 0x0a, 0x0e, 0x4c, 0x69, 0x73,
-	0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x23, 0x2e, 0x6d, 0x65,
-	0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54,
-	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x24, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09,
-	0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x72, 0x0a, 0x0d, 0x47, 0x65, 0x74,
-	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x64,
-	0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
-	0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x74, 0x6f,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68, 0x61, 0x73, 0x68, 0x7d, 0x12, 0x83, 0x01,
-	0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66,
-	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x65,
-	0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x74,
-	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68, 0x61, 0x73, 0x68, 0x7d, 0x2f, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x83, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x66, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x67, 0x65, 0x22, 0x5c, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65,
+	0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78,
+	0x74, 0x22, 0x41, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x22, 0x63, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x32, 0x8f, 0x0a, 0x0a, 0x0a, 0x57, 0x65,
+	0x62, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6e, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x23, 0x2e, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x24, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f,
+	0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x72, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x64, 0x69,
 	0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x4d, 0x65,
-	0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x17, 0x12, 0x15, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68,
-	0x61, 0x73, 0x68, 0x7d, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x7b, 0x0a, 0x10, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x25, 0x2e,
-	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f,
-	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0xaf, 0x01, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x6d,
-	0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x57, 0x65, 0x62,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
-	0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x65, 0x6e, 0x65,
-	0x72, 0x6d, 0x65, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x2f, 0x70, 0x6b, 0x67,
-	0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2f, 0x77, 0x65, 0x62, 0x2f,
-	0x76, 0x31, 0x3b, 0x77, 0x65, 0x62, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x4d, 0x57, 0x58, 0xaa, 0x02,
-	0x0c, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x57, 0x65, 0x62, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0c,
-	0x4d, 0x65, 0x64, 0x69, 0x61, 0x5c, 0x57, 0x65, 0x62, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x18, 0x4d,
-	0x65, 0x64, 0x69, 0x61, 0x5c, 0x57, 0x65, 0x62, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x3a,
-	0x3a, 0x57, 0x65, 0x62, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x74, 0x6f, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68, 0x61, 0x73, 0x68, 0x7d, 0x12, 0x83, 0x01, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x74, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68, 0x61, 0x73, 0x68, 0x7d, 0x2f, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x83, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x66, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x4d, 0x65, 0x74,
+	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x17, 0x12, 0x15, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68, 0x61,
+	0x73, 0x68, 0x7d, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x7e, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x2e, 0x6d, 0x65,
+	0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18,
+	0x12, 0x16, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68, 0x61, 0x73,
+	0x68, 0x7d, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x92, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x29, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d,
+	0x12, 0x1b, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x68, 0x61, 0x73,
+	0x68, 0x7d, 0x2f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x91, 0x01,
+	0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x54, 0x6f,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x6f, 0x6f,
+	0x74, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x29, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x54, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x70, 0x69, 0x65, 0x63,
+	0x65, 0x73, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x7d, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x7b, 0x0a, 0x10, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x25, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x74,
+	0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x65,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x20, 0x2e,
+	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x83, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x65, 0x72, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x2e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x73, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x42, 0xaf, 0x01, 0x0a, 0x10,
+	0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x77, 0x65, 0x62, 0x2e, 0x76, 0x31,
+	0x42, 0x10, 0x57, 0x65, 0x62, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x77, 0x65, 0x6e, 0x65, 0x72, 0x6d, 0x65, 0x2f, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x2f, 0x77, 0x65, 0x62, 0x2f, 0x76, 0x31, 0x3b, 0x77, 0x65, 0x62, 0x76, 0x31, 0xa2, 0x02, 0x03,
+	0x4d, 0x57, 0x58, 0xaa, 0x02, 0x0c, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x57, 0x65, 0x62, 0x2e,
+	0x56, 0x31, 0xca, 0x02, 0x0c, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x5c, 0x57, 0x65, 0x62, 0x5c, 0x56,
+	0x31, 0xe2, 0x02, 0x18, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x5c, 0x57, 0x65, 0x62, 0x5c, 0x56, 0x31,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x4d,
+	0x65, 0x64, 0x69, 0x61, 0x3a, 0x3a, 0x57, 0x65, 0x62, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -998,49 +2187,85 @@ func file_media_web_v1_web_services_proto_rawDescGZIP() []byte {
 }
 
 var (
-	file_media_web_v1_web_services_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+	file_media_web_v1_web_services_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 	file_media_web_v1_web_services_proto_goTypes  = []interface{}{
-		(*GetTorrentRefDataRequest)(nil),  // 0: media.web.v1.GetTorrentRefDataRequest
-		(*GetTorrentRefDataResponse)(nil), // 1: media.web.v1.GetTorrentRefDataResponse
-		(*GetTorrentRefMetaRequest)(nil),  // 2: media.web.v1.GetTorrentRefMetaRequest
-		(*GetTorrentRefMetaResponse)(nil), // 3: media.web.v1.GetTorrentRefMetaResponse
-		(*SearchTorrentRefRequest)(nil),   // 4: media.web.v1.SearchTorrentRefRequest
-		(*SearchTorrentRefResponse)(nil),  // 5: media.web.v1.SearchTorrentRefResponse
-		(*SearchTorrentRef)(nil),          // 6: media.web.v1.SearchTorrentRef
-		(*GetTorrentRefRequest)(nil),      // 7: media.web.v1.GetTorrentRefRequest
-		(*GetTorrentRefResponse)(nil),     // 8: media.web.v1.GetTorrentRefResponse
-		(*TorrentRef)(nil),                // 9: media.web.v1.TorrentRef
-		(*Torrent)(nil),                   // 10: media.web.v1.Torrent
-		(*ListTorrentRefRequest)(nil),     // 11: media.web.v1.ListTorrentRefRequest
-		(*ListTorrentRefResponse)(nil),    // 12: media.web.v1.ListTorrentRefResponse
-		(*structpb.Struct)(nil),           // 13: google.protobuf.Struct
-		(*timestamppb.Timestamp)(nil),     // 14: google.protobuf.Timestamp
+		(*GetTorrentRefDataRequest)(nil),     // 0: media.web.v1.GetTorrentRefDataRequest
+		(*GetTorrentRefDataResponse)(nil),    // 1: media.web.v1.GetTorrentRefDataResponse
+		(*GetTorrentRefMetaRequest)(nil),     // 2: media.web.v1.GetTorrentRefMetaRequest
+		(*GetTorrentRefMetaResponse)(nil),    // 3: media.web.v1.GetTorrentRefMetaResponse
+		(*SearchTorrentRefRequest)(nil),      // 4: media.web.v1.SearchTorrentRefRequest
+		(*SearchTorrentRefResponse)(nil),     // 5: media.web.v1.SearchTorrentRefResponse
+		(*SearchFacet)(nil),                  // 6: media.web.v1.SearchFacet
+		(*SearchFacetValue)(nil),             // 7: media.web.v1.SearchFacetValue
+		(*SearchTorrentRef)(nil),             // 8: media.web.v1.SearchTorrentRef
+		(*SearchFileMatch)(nil),              // 9: media.web.v1.SearchFileMatch
+		(*GetTorrentRefRequest)(nil),         // 10: media.web.v1.GetTorrentRefRequest
+		(*GetTorrentRefResponse)(nil),        // 11: media.web.v1.GetTorrentRefResponse
+		(*TorrentRef)(nil),                   // 12: media.web.v1.TorrentRef
+		(*Torrent)(nil),                      // 13: media.web.v1.Torrent
+		(*ListDuplicateTorrentRequest)(nil),  // 14: media.web.v1.ListDuplicateTorrentRequest
+		(*ListDuplicateTorrentResponse)(nil), // 15: media.web.v1.ListDuplicateTorrentResponse
+		(*ListFileRootTorrentRequest)(nil),   // 16: media.web.v1.ListFileRootTorrentRequest
+		(*ListFileRootTorrentResponse)(nil),  // 17: media.web.v1.ListFileRootTorrentResponse
+		(*FileRootTorrent)(nil),              // 18: media.web.v1.FileRootTorrent
+		(*ListTorrentRefRequest)(nil),        // 19: media.web.v1.ListTorrentRefRequest
+		(*ListTorrentRefResponse)(nil),       // 20: media.web.v1.ListTorrentRefResponse
+		(*Tracker)(nil),                      // 21: media.web.v1.Tracker
+		(*TorrentFile)(nil),                  // 22: media.web.v1.TorrentFile
+		(*ListTorrentFileRequest)(nil),       // 23: media.web.v1.ListTorrentFileRequest
+		(*ListTorrentFileResponse)(nil),      // 24: media.web.v1.ListTorrentFileResponse
+		(*ListTrackerRequest)(nil),           // 25: media.web.v1.ListTrackerRequest
+		(*ListTrackerResponse)(nil),          // 26: media.web.v1.ListTrackerResponse
+		(*ListTrackerTorrentRequest)(nil),    // 27: media.web.v1.ListTrackerTorrentRequest
+		(*ListTrackerTorrentResponse)(nil),   // 28: media.web.v1.ListTrackerTorrentResponse
+		(*structpb.Struct)(nil),              // 29: google.protobuf.Struct
+		(*timestamppb.Timestamp)(nil),        // 30: google.protobuf.Timestamp
 	}
 )
 var file_media_web_v1_web_services_proto_depIdxs = []int32{
-	9,  // 0: media.web.v1.GetTorrentRefDataResponse.item:type_name -> media.web.v1.TorrentRef
-	13, // 1: media.web.v1.GetTorrentRefMetaResponse.meta:type_name -> google.protobuf.Struct
-	6,  // 2: media.web.v1.SearchTorrentRefResponse.items:type_name -> media.web.v1.SearchTorrentRef
-	9,  // 3: media.web.v1.SearchTorrentRef.item:type_name -> media.web.v1.TorrentRef
-	10, // 4: media.web.v1.GetTorrentRefResponse.item:type_name -> media.web.v1.Torrent
-	14, // 5: media.web.v1.TorrentRef.created_at:type_name -> google.protobuf.Timestamp
-	10, // 6: media.web.v1.TorrentRef.torrent:type_name -> media.web.v1.Torrent
-	9,  // 7: media.web.v1.ListTorrentRefResponse.items:type_name -> media.web.v1.TorrentRef
-	11, // 8: media.web.v1.WebService.ListTorrentRef:input_type -> media.web.v1.ListTorrentRefRequest
-	7,  // 9: media.web.v1.WebService.GetTorrentRef:input_type -> media.web.v1.GetTorrentRefRequest
-	0,  // 10: media.web.v1.WebService.GetTorrentRefData:input_type -> media.web.v1.GetTorrentRefDataRequest
-	2,  // 11: media.web.v1.WebService.GetTorrentRefMeta:input_type -> media.web.v1.GetTorrentRefMetaRequest
-	4,  // 12: media.web.v1.WebService.SearchTorrentRef:input_type -> media.web.v1.SearchTorrentRefRequest
-	12, // 13: media.web.v1.WebService.ListTorrentRef:output_type -> media.web.v1.ListTorrentRefResponse
-	8,  // 14: media.web.v1.WebService.GetTorrentRef:output_type -> media.web.v1.GetTorrentRefResponse
-	1,  // 15: media.web.v1.WebService.GetTorrentRefData:output_type -> media.web.v1.GetTorrentRefDataResponse
-	3,  // 16: media.web.v1.WebService.GetTorrentRefMeta:output_type -> media.web.v1.GetTorrentRefMetaResponse
-	5,  // 17: media.web.v1.WebService.SearchTorrentRef:output_type -> media.web.v1.SearchTorrentRefResponse
-	13, // [13:18] is the sub-list for method output_type
-	8,  // [8:13] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	12, // 0: media.web.v1.GetTorrentRefDataResponse.item:type_name -> media.web.v1.TorrentRef
+	29, // 1: media.web.v1.GetTorrentRefMetaResponse.meta:type_name -> google.protobuf.Struct
+	8,  // 2: media.web.v1.SearchTorrentRefResponse.items:type_name -> media.web.v1.SearchTorrentRef
+	6,  // 3: media.web.v1.SearchTorrentRefResponse.facets:type_name -> media.web.v1.SearchFacet
+	7,  // 4: media.web.v1.SearchFacet.values:type_name -> media.web.v1.SearchFacetValue
+	12, // 5: media.web.v1.SearchTorrentRef.item:type_name -> media.web.v1.TorrentRef
+	9,  // 6: media.web.v1.SearchTorrentRef.files:type_name -> media.web.v1.SearchFileMatch
+	13, // 7: media.web.v1.GetTorrentRefResponse.item:type_name -> media.web.v1.Torrent
+	30, // 8: media.web.v1.TorrentRef.created_at:type_name -> google.protobuf.Timestamp
+	13, // 9: media.web.v1.TorrentRef.torrent:type_name -> media.web.v1.Torrent
+	13, // 10: media.web.v1.ListDuplicateTorrentResponse.items:type_name -> media.web.v1.Torrent
+	18, // 11: media.web.v1.ListFileRootTorrentResponse.items:type_name -> media.web.v1.FileRootTorrent
+	13, // 12: media.web.v1.FileRootTorrent.torrent:type_name -> media.web.v1.Torrent
+	22, // 13: media.web.v1.FileRootTorrent.file:type_name -> media.web.v1.TorrentFile
+	12, // 14: media.web.v1.ListTorrentRefResponse.items:type_name -> media.web.v1.TorrentRef
+	22, // 15: media.web.v1.ListTorrentFileResponse.items:type_name -> media.web.v1.TorrentFile
+	21, // 16: media.web.v1.ListTrackerResponse.items:type_name -> media.web.v1.Tracker
+	13, // 17: media.web.v1.ListTrackerTorrentResponse.items:type_name -> media.web.v1.Torrent
+	19, // 18: media.web.v1.WebService.ListTorrentRef:input_type -> media.web.v1.ListTorrentRefRequest
+	10, // 19: media.web.v1.WebService.GetTorrentRef:input_type -> media.web.v1.GetTorrentRefRequest
+	0,  // 20: media.web.v1.WebService.GetTorrentRefData:input_type -> media.web.v1.GetTorrentRefDataRequest
+	2,  // 21: media.web.v1.WebService.GetTorrentRefMeta:input_type -> media.web.v1.GetTorrentRefMetaRequest
+	23, // 22: media.web.v1.WebService.ListTorrentFile:input_type -> media.web.v1.ListTorrentFileRequest
+	14, // 23: media.web.v1.WebService.ListDuplicateTorrent:input_type -> media.web.v1.ListDuplicateTorrentRequest
+	16, // 24: media.web.v1.WebService.ListFileRootTorrent:input_type -> media.web.v1.ListFileRootTorrentRequest
+	4,  // 25: media.web.v1.WebService.SearchTorrentRef:input_type -> media.web.v1.SearchTorrentRefRequest
+	25, // 26: media.web.v1.WebService.ListTracker:input_type -> media.web.v1.ListTrackerRequest
+	27, // 27: media.web.v1.WebService.ListTrackerTorrent:input_type -> media.web.v1.ListTrackerTorrentRequest
+	20, // 28: media.web.v1.WebService.ListTorrentRef:output_type -> media.web.v1.ListTorrentRefResponse
+	11, // 29: media.web.v1.WebService.GetTorrentRef:output_type -> media.web.v1.GetTorrentRefResponse
+	1,  // 30: media.web.v1.WebService.GetTorrentRefData:output_type -> media.web.v1.GetTorrentRefDataResponse
+	3,  // 31: media.web.v1.WebService.GetTorrentRefMeta:output_type -> media.web.v1.GetTorrentRefMetaResponse
+	24, // 32: media.web.v1.WebService.ListTorrentFile:output_type -> media.web.v1.ListTorrentFileResponse
+	15, // 33: media.web.v1.WebService.ListDuplicateTorrent:output_type -> media.web.v1.ListDuplicateTorrentResponse
+	17, // 34: media.web.v1.WebService.ListFileRootTorrent:output_type -> media.web.v1.ListFileRootTorrentResponse
+	5,  // 35: media.web.v1.WebService.SearchTorrentRef:output_type -> media.web.v1.SearchTorrentRefResponse
+	26, // 36: media.web.v1.WebService.ListTracker:output_type -> media.web.v1.ListTrackerResponse
+	28, // 37: media.web.v1.WebService.ListTrackerTorrent:output_type -> media.web.v1.ListTrackerTorrentResponse
+	28, // [28:38] is the sub-list for method output_type
+	18, // [18:28] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_media_web_v1_web_services_proto_init() }
@@ -1122,7 +2347,7 @@ func file_media_web_v1_web_services_proto_init() {
 			}
 		}
 		file_media_web_v1_web_services_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchTorrentRef); i {
+			switch v := v.(*SearchFacet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1134,7 +2359,7 @@ func file_media_web_v1_web_services_proto_init() {
 			}
 		}
 		file_media_web_v1_web_services_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTorrentRefRequest); i {
+			switch v := v.(*SearchFacetValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1146,7 +2371,7 @@ func file_media_web_v1_web_services_proto_init() {
 			}
 		}
 		file_media_web_v1_web_services_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTorrentRefResponse); i {
+			switch v := v.(*SearchTorrentRef); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1158,7 +2383,7 @@ func file_media_web_v1_web_services_proto_init() {
 			}
 		}
 		file_media_web_v1_web_services_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TorrentRef); i {
+			switch v := v.(*SearchFileMatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1170,7 +2395,7 @@ func file_media_web_v1_web_services_proto_init() {
 			}
 		}
 		file_media_web_v1_web_services_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Torrent); i {
+			switch v := v.(*GetTorrentRefRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1182,7 +2407,7 @@ func file_media_web_v1_web_services_proto_init() {
 			}
 		}
 		file_media_web_v1_web_services_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListTorrentRefRequest); i {
+			switch v := v.(*GetTorrentRefResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1194,6 +2419,102 @@ func file_media_web_v1_web_services_proto_init() {
 			}
 		}
 		file_media_web_v1_web_services_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TorrentRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Torrent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDuplicateTorrentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDuplicateTorrentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFileRootTorrentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFileRootTorrentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileRootTorrent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTorrentRefRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListTorrentRefResponse); i {
 			case 0:
 				return &v.state
@@ -1205,15 +2526,111 @@ func file_media_web_v1_web_services_proto_init() {
 				return nil
 			}
 		}
+		file_media_web_v1_web_services_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tracker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TorrentFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTorrentFileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTorrentFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackerTorrentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_media_web_v1_web_services_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackerTorrentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_media_web_v1_web_services_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	file_media_web_v1_web_services_proto_msgTypes[12].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_media_web_v1_web_services_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,7 +27,14 @@ type WebServiceClient interface {
 	GetTorrentRef(ctx context.Context, in *GetTorrentRefRequest, opts ...grpc.CallOption) (*GetTorrentRefResponse, error)
 	GetTorrentRefData(ctx context.Context, in *GetTorrentRefDataRequest, opts ...grpc.CallOption) (*GetTorrentRefDataResponse, error)
 	GetTorrentRefMeta(ctx context.Context, in *GetTorrentRefMetaRequest, opts ...grpc.CallOption) (*GetTorrentRefMetaResponse, error)
+	ListTorrentFile(ctx context.Context, in *ListTorrentFileRequest, opts ...grpc.CallOption) (*ListTorrentFileResponse, error)
+	// list other torrents with same content
+	ListDuplicateTorrent(ctx context.Context, in *ListDuplicateTorrentRequest, opts ...grpc.CallOption) (*ListDuplicateTorrentResponse, error)
+	// find torrents contain the file with v2 pieces root
+	ListFileRootTorrent(ctx context.Context, in *ListFileRootTorrentRequest, opts ...grpc.CallOption) (*ListFileRootTorrentResponse, error)
 	SearchTorrentRef(ctx context.Context, in *SearchTorrentRefRequest, opts ...grpc.CallOption) (*SearchTorrentRefResponse, error)
+	ListTracker(ctx context.Context, in *ListTrackerRequest, opts ...grpc.CallOption) (*ListTrackerResponse, error)
+	ListTrackerTorrent(ctx context.Context, in *ListTrackerTorrentRequest, opts ...grpc.CallOption) (*ListTrackerTorrentResponse, error)
 }
 
 type webServiceClient struct {
@@ -74,6 +81,33 @@ func (c *webServiceClient) GetTorrentRefMeta(ctx context.Context, in *GetTorrent
 	return out, nil
 }
 
+func (c *webServiceClient) ListTorrentFile(ctx context.Context, in *ListTorrentFileRequest, opts ...grpc.CallOption) (*ListTorrentFileResponse, error) {
+	out := new(ListTorrentFileResponse)
+	err := c.cc.Invoke(ctx, "/media.web.v1.WebService/ListTorrentFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webServiceClient) ListDuplicateTorrent(ctx context.Context, in *ListDuplicateTorrentRequest, opts ...grpc.CallOption) (*ListDuplicateTorrentResponse, error) {
+	out := new(ListDuplicateTorrentResponse)
+	err := c.cc.Invoke(ctx, "/media.web.v1.WebService/ListDuplicateTorrent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webServiceClient) ListFileRootTorrent(ctx context.Context, in *ListFileRootTorrentRequest, opts ...grpc.CallOption) (*ListFileRootTorrentResponse, error) {
+	out := new(ListFileRootTorrentResponse)
+	err := c.cc.Invoke(ctx, "/media.web.v1.WebService/ListFileRootTorrent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *webServiceClient) SearchTorrentRef(ctx context.Context, in *SearchTorrentRefRequest, opts ...grpc.CallOption) (*SearchTorrentRefResponse, error) {
 	out := new(SearchTorrentRefResponse)
 	err := c.cc.Invoke(ctx, "/media.web.v1.WebService/SearchTorrentRef", in, out, opts...)
@@ -83,6 +117,24 @@ func (c *webServiceClient) SearchTorrentRef(ctx context.Context, in *SearchTorre
 	return out, nil
 }
 
+func (c *webServiceClient) ListTracker(ctx context.Context, in *ListTrackerRequest, opts ...grpc.CallOption) (*ListTrackerResponse, error) {
+	out := new(ListTrackerResponse)
+	err := c.cc.Invoke(ctx, "/media.web.v1.WebService/ListTracker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webServiceClient) ListTrackerTorrent(ctx context.Context, in *ListTrackerTorrentRequest, opts ...grpc.CallOption) (*ListTrackerTorrentResponse, error) {
+	out := new(ListTrackerTorrentResponse)
+	err := c.cc.Invoke(ctx, "/media.web.v1.WebService/ListTrackerTorrent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WebServiceServer is the server API for WebService service.
 // All implementations must embed UnimplementedWebServiceServer
 // for forward compatibility
@@ -91,7 +143,14 @@ type WebServiceServer interface {
 	GetTorrentRef(context.Context, *GetTorrentRefRequest) (*GetTorrentRefResponse, error)
 	GetTorrentRefData(context.Context, *GetTorrentRefDataRequest) (*GetTorrentRefDataResponse, error)
 	GetTorrentRefMeta(context.Context, *GetTorrentRefMetaRequest) (*GetTorrentRefMetaResponse, error)
+	ListTorrentFile(context.Context, *ListTorrentFileRequest) (*ListTorrentFileResponse, error)
+	// list other torrents with same content
+	ListDuplicateTorrent(context.Context, *ListDuplicateTorrentRequest) (*ListDuplicateTorrentResponse, error)
+	// find torrents contain the file with v2 pieces root
+	ListFileRootTorrent(context.Context, *ListFileRootTorrentRequest) (*ListFileRootTorrentResponse, error)
 	SearchTorrentRef(context.Context, *SearchTorrentRefRequest) (*SearchTorrentRefResponse, error)
+	ListTracker(context.Context, *ListTrackerRequest) (*ListTrackerResponse, error)
+	ListTrackerTorrent(context.Context, *ListTrackerTorrentRequest) (*ListTrackerTorrentResponse, error)
 	mustEmbedUnimplementedWebServiceServer()
 }
 
@@ -114,9 +173,29 @@ func (UnimplementedWebServiceServer) GetTorrentRefMeta(context.Context, *GetTorr
 	return nil, status.Errorf(codes.Unimplemented, "method GetTorrentRefMeta not implemented")
 }
 
+func (UnimplementedWebServiceServer) ListTorrentFile(context.Context, *ListTorrentFileRequest) (*ListTorrentFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTorrentFile not implemented")
+}
+
+func (UnimplementedWebServiceServer) ListDuplicateTorrent(context.Context, *ListDuplicateTorrentRequest) (*ListDuplicateTorrentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDuplicateTorrent not implemented")
+}
+
+func (UnimplementedWebServiceServer) ListFileRootTorrent(context.Context, *ListFileRootTorrentRequest) (*ListFileRootTorrentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFileRootTorrent not implemented")
+}
+
 func (UnimplementedWebServiceServer) SearchTorrentRef(context.Context, *SearchTorrentRefRequest) (*SearchTorrentRefResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchTorrentRef not implemented")
 }
+
+func (UnimplementedWebServiceServer) ListTracker(context.Context, *ListTrackerRequest) (*ListTrackerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTracker not implemented")
+}
+
+func (UnimplementedWebServiceServer) ListTrackerTorrent(context.Context, *ListTrackerTorrentRequest) (*ListTrackerTorrentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrackerTorrent not implemented")
+}
 func (UnimplementedWebServiceServer) mustEmbedUnimplementedWebServiceServer() {}
 
 // UnsafeWebServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -202,6 +281,60 @@ func _WebService_GetTorrentRefMeta_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WebService_ListTorrentFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTorrentFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebServiceServer).ListTorrentFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.web.v1.WebService/ListTorrentFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebServiceServer).ListTorrentFile(ctx, req.(*ListTorrentFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebService_ListDuplicateTorrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDuplicateTorrentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebServiceServer).ListDuplicateTorrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.web.v1.WebService/ListDuplicateTorrent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebServiceServer).ListDuplicateTorrent(ctx, req.(*ListDuplicateTorrentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebService_ListFileRootTorrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileRootTorrentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebServiceServer).ListFileRootTorrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.web.v1.WebService/ListFileRootTorrent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebServiceServer).ListFileRootTorrent(ctx, req.(*ListFileRootTorrentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WebService_SearchTorrentRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchTorrentRefRequest)
 	if err := dec(in); err != nil {
@@ -220,6 +353,42 @@ func _WebService_SearchTorrentRef_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WebService_ListTracker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrackerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebServiceServer).ListTracker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.web.v1.WebService/ListTracker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebServiceServer).ListTracker(ctx, req.(*ListTrackerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebService_ListTrackerTorrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrackerTorrentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebServiceServer).ListTrackerTorrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/media.web.v1.WebService/ListTrackerTorrent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebServiceServer).ListTrackerTorrent(ctx, req.(*ListTrackerTorrentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WebService_ServiceDesc is the grpc.ServiceDesc for WebService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,10 +412,30 @@ var WebService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTorrentRefMeta",
 			Handler:    _WebService_GetTorrentRefMeta_Handler,
 		},
+		{
+			MethodName: "ListTorrentFile",
+			Handler:    _WebService_ListTorrentFile_Handler,
+		},
+		{
+			MethodName: "ListDuplicateTorrent",
+			Handler:    _WebService_ListDuplicateTorrent_Handler,
+		},
+		{
+			MethodName: "ListFileRootTorrent",
+			Handler:    _WebService_ListFileRootTorrent_Handler,
+		},
 		{
 			MethodName: "SearchTorrentRef",
 			Handler:    _WebService_SearchTorrentRef_Handler,
 		},
+		{
+			MethodName: "ListTracker",
+			Handler:    _WebService_ListTracker_Handler,
+		},
+		{
+			MethodName: "ListTrackerTorrent",
+			Handler:    _WebService_ListTrackerTorrent_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "media/web/v1/web_services.proto",
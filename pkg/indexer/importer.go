@@ -0,0 +1,234 @@
+package indexer
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/wenerme/torrenti/pkg/scrape/handlers"
+	"github.com/wenerme/torrenti/pkg/scrape/handlers/archives"
+	"github.com/wenerme/torrenti/pkg/subi"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+type ImportStatus string
+
+const (
+	ImportStatusNew         ImportStatus = "new"
+	ImportStatusDuplicate   ImportStatus = "duplicate"
+	ImportStatusQuarantined ImportStatus = "quarantined"
+	ImportStatusFailed      ImportStatus = "failed"
+	ImportStatusSkipped     ImportStatus = "skipped"
+	ImportStatusDeleted     ImportStatus = "deleted" // tombstoned torrent
+)
+
+// ImportResult is the result of one file, archive entry path is archive!entry
+type ImportResult struct {
+	Path   string       `json:"path"`
+	Status ImportStatus `json:"status"`
+	Hash   string       `json:"hash,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+type ImportSummary struct {
+	New         int             `json:"new"`
+	Duplicate   int             `json:"duplicate"`
+	Quarantined int             `json:"quarantined"`
+	Failed      int             `json:"failed"`
+	Skipped     int             `json:"skipped"`
+	Deleted     int             `json:"deleted"`
+	Files       []*ImportResult `json:"files,omitempty"`
+}
+
+func (s *ImportSummary) Add(r *ImportResult) {
+	switch r.Status {
+	case ImportStatusNew:
+		s.New++
+	case ImportStatusDuplicate:
+		s.Duplicate++
+	case ImportStatusQuarantined:
+		s.Quarantined++
+	case ImportStatusFailed:
+		s.Failed++
+	case ImportStatusSkipped:
+		s.Skipped++
+	case ImportStatusDeleted:
+		s.Deleted++
+	}
+}
+
+// Importer bulk import .torrent files from directories and archives
+type Importer struct {
+	Torrent  *torrenti.Service
+	Subtitle *subi.Indexer // optional, subtitles are skipped if nil
+	Workers  int           // default to number of cpu
+}
+
+// Import walk paths recursively and index all torrents, bad files are reported and skipped
+//
+// onResult is called serially, summary keep only counts when onResult is set.
+func (im *Importer) Import(ctx context.Context, paths []string, onResult func(r *ImportResult)) (sum *ImportSummary, err error) {
+	if im.Torrent == nil {
+		return nil, errors.New("torrent indexer is nil")
+	}
+	sum = &ImportSummary{}
+	var mu sync.Mutex
+	report := func(r *ImportResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		sum.Add(r)
+		if onResult != nil {
+			onResult(r)
+		} else {
+			sum.Files = append(sum.Files, r)
+		}
+	}
+
+	workers := im.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	files := make(chan string, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fn := range files {
+				im.importFile(ctx, fn, report)
+			}
+		}()
+	}
+
+	for _, root := range paths {
+		err = filepath.WalkDir(root, func(fn string, d fs.DirEntry, err error) error {
+			if err != nil {
+				report(&ImportResult{Path: fn, Status: ImportStatusFailed, Error: err.Error()})
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if fn != root && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || !d.Type().IsRegular() {
+				return nil
+			}
+			select {
+			case files <- fn:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			break
+		}
+	}
+	close(files)
+	wg.Wait()
+	return
+}
+
+func (im *Importer) importFile(ctx context.Context, fn string, report func(r *ImportResult)) {
+	if ctx.Err() != nil {
+		return
+	}
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		report(&ImportResult{Path: fn, Status: ImportStatusFailed, Error: err.Error()})
+		return
+	}
+	f := &util.File{Path: fn, Length: int64(len(data)), Data: data}
+	if fi, err := os.Stat(fn); err == nil {
+		f.Modified = fi.ModTime()
+	}
+	im.handle(ctx, fn, f, report)
+}
+
+// handle index torrent or extract archive, name is the display path of file
+func (im *Importer) handle(ctx context.Context, name string, f *util.File, report func(r *ImportResult)) {
+	defer func() {
+		if r := recover(); r != nil {
+			report(&ImportResult{Path: name, Status: ImportStatusFailed, Error: errors.Errorf("handle panic: %v", r).Error()})
+		}
+	}()
+
+	var unarchive func(ctx context.Context, in *util.File, cb func(context.Context, *util.File) error) error
+	switch ext := handlers.FileExt(f); ext {
+	case ".torrent":
+		report(im.indexTorrent(ctx, name, f))
+		return
+	case ".zip":
+		unarchive = archives.Unzip
+	case ".rar":
+		unarchive = archives.Unrar
+	case ".7z":
+		unarchive = archives.Un7z
+	default:
+		if handlers.IsSubtitleExt(ext) && im.Subtitle != nil {
+			report(im.indexSubtitle(name, f))
+			return
+		}
+		report(&ImportResult{Path: name, Status: ImportStatusSkipped})
+		return
+	}
+
+	err := unarchive(ctx, f, func(ctx context.Context, entry *util.File) error {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		im.handle(ctx, name+"!"+entry.Path, entry, report)
+		return ctx.Err()
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Warn().Err(err).Str("file", name).Msg("extract archive")
+		report(&ImportResult{Path: name, Status: ImportStatusFailed, Error: errors.Wrap(err, "extract archive").Error()})
+	}
+}
+
+func (im *Importer) indexTorrent(ctx context.Context, name string, f *util.File) *ImportResult {
+	r := &ImportResult{Path: name}
+	t := &torrenti.Torrent{
+		Data:     f.Data,
+		FileInfo: f,
+	}
+	stat, err := im.Torrent.IndexTorrent(ctx, t)
+	if !t.Hash.IsZero() {
+		r.Hash = t.Hash.String()
+	}
+	switch {
+	case err != nil:
+		r.Status = ImportStatusFailed
+		r.Error = err.Error()
+	case stat.TombstoneCount > 0:
+		r.Status = ImportStatusDeleted
+	case stat.QuarantinedCount > 0:
+		r.Status = ImportStatusQuarantined
+	case stat.MetaCount > 0:
+		r.Status = ImportStatusNew
+	default:
+		r.Status = ImportStatusDuplicate
+	}
+	return r
+}
+
+func (im *Importer) indexSubtitle(name string, f *util.File) *ImportResult {
+	r := &ImportResult{Path: name, Status: ImportStatusNew}
+	if err := im.Subtitle.Index(f); err != nil {
+		r.Status = ImportStatusFailed
+		r.Error = err.Error()
+	}
+	return r
+}
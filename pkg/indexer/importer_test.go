@@ -0,0 +1,72 @@
+package indexer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/util/testx"
+	"github.com/xgfone/bt/bencode"
+)
+
+func newTestService(t *testing.T) *torrenti.Service {
+	svc, err := torrenti.NewIndexer(torrenti.NewServiceOptions{DB: testx.NewMemoryDB(t)})
+	assert.NoError(t, err)
+	return svc
+}
+
+func newTorrent(t *testing.T, name string) []byte {
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":         name,
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 20)),
+			"length":       10,
+		},
+	})
+	assert.NoError(t, err)
+	return data
+}
+
+func TestImport(t *testing.T) {
+	svc := newTestService(t)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub", ".hidden"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.torrent"), newTorrent(t, "a"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.torrent"), newTorrent(t, "b"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", ".hidden", "c.torrent"), newTorrent(t, "c"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "bad.torrent"), []byte("bad"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("hello"), 0o644))
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, v := range []string{"a", "d"} {
+		w, err := zw.Create("dump/" + v + ".torrent")
+		assert.NoError(t, err)
+		_, err = w.Write(newTorrent(t, v))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dump.zip"), buf.Bytes(), 0o644))
+
+	im := &Importer{Torrent: svc, Workers: 2}
+	sum, err := im.Import(context.Background(), []string{dir}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sum.New)
+	assert.Equal(t, 1, sum.Duplicate)
+	assert.Equal(t, 1, sum.Failed)
+	assert.Equal(t, 1, sum.Skipped)
+	assert.Len(t, sum.Files, 6)
+
+	sum, err = im.Import(context.Background(), []string{filepath.Join(dir, "dump.zip")}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sum.New)
+	assert.Equal(t, 2, sum.Duplicate)
+	assert.Equal(t, filepath.Join(dir, "dump.zip")+"!dump/a.torrent", sum.Files[0].Path)
+}
@@ -0,0 +1,245 @@
+package indexer
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// status of processed file, also the sub dir of watch dir file moved to
+const (
+	WatchStatusDone   = "done"
+	WatchStatusFailed = "failed"
+)
+
+type NewWatcherOptions struct {
+	Dirs     []string
+	Interval time.Duration // poll interval, default to 10s
+	Importer *Importer
+	DB       *gorm.DB // store processed files
+}
+
+// Watcher poll watch dirs, index dropped files and move them to done or failed dir
+type Watcher struct {
+	Dirs     []string
+	Interval time.Duration
+	Importer *Importer
+	DB       *gorm.DB
+
+	pending map[string]watchState
+}
+
+// watchState detect file still being written
+type watchState struct {
+	size    int64
+	modTime time.Time
+}
+
+func NewWatcher(o NewWatcherOptions) (*Watcher, error) {
+	if o.Importer == nil {
+		return nil, errors.New("importer is nil")
+	}
+	if o.DB == nil {
+		return nil, errors.New("db is nil")
+	}
+	if o.Interval <= 0 {
+		o.Interval = 10 * time.Second
+	}
+	w := &Watcher{
+		Dirs:     o.Dirs,
+		Interval: o.Interval,
+		Importer: o.Importer,
+		DB:       o.DB,
+		pending:  map[string]watchState{},
+	}
+	if err := w.DB.Migrator().AutoMigrate(models.WatchFile{}); err != nil {
+		return nil, err
+	}
+	for _, dir := range w.Dirs {
+		for _, v := range []string{WatchStatusDone, WatchStatusFailed} {
+			if err := os.MkdirAll(filepath.Join(dir, v), 0o755); err != nil {
+				return nil, errors.Wrap(err, "make watch dir")
+			}
+		}
+	}
+	return w, nil
+}
+
+// Run poll until context done
+func (w *Watcher) Run(ctx context.Context) error {
+	log.Info().Strs("dirs", w.Dirs).Dur("interval", w.Interval).Msg("watch dirs")
+	t := time.NewTicker(w.Interval)
+	defer t.Stop()
+	for {
+		if err := w.Scan(ctx); err != nil {
+			log.Err(err).Msg("scan watch dirs")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// Scan process files unchanged since last scan, unreadable dir is logged and skipped
+func (w *Watcher) Scan(ctx context.Context) (err error) {
+	found := map[string]bool{}
+	var failed []string
+	for _, dir := range w.Dirs {
+		err = filepath.WalkDir(dir, func(fn string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if fn == dir {
+					return err
+				}
+				log.Warn().Err(err).Str("path", fn).Msg("skip unreadable watch path")
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if fn == dir {
+				return nil
+			}
+			if d.IsDir() {
+				if strings.HasPrefix(d.Name(), ".") || filepath.Dir(fn) == dir && (d.Name() == WatchStatusDone || d.Name() == WatchStatusFailed) {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") || !d.Type().IsRegular() {
+				return nil
+			}
+			fi, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			found[fn] = true
+			st := watchState{size: fi.Size(), modTime: fi.ModTime()}
+			if last, ok := w.pending[fn]; !ok || last != st {
+				w.pending[fn] = st
+				return nil
+			}
+			delete(w.pending, fn)
+			w.process(ctx, dir, fn)
+			return nil
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			log.Err(err).Str("dir", dir).Msg("scan watch dir")
+			failed = append(failed, dir+string(filepath.Separator))
+			err = nil
+		}
+	}
+	for k := range w.pending {
+		// keep state of files in dir failed to scan
+		if !found[k] && !lo.ContainsBy(failed, func(dir string) bool {
+			return strings.HasPrefix(k, dir)
+		}) {
+			delete(w.pending, k)
+		}
+	}
+	return
+}
+
+func (w *Watcher) process(ctx context.Context, dir string, fn string) {
+	l := log.With().Str("file", fn).Logger()
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		l.Err(err).Msg("read watch file")
+		return
+	}
+	wf := models.WatchFile{
+		ContentHash: util.ContentHashBytes(data),
+		Filename:    filepath.Base(fn),
+		Status:      WatchStatusDone,
+	}
+
+	var last models.WatchFile
+	err = w.DB.WithContext(ctx).Where(models.WatchFile{ContentHash: wf.ContentHash}).Limit(1).Find(&last).Error
+	if err != nil {
+		l.Err(err).Msg("find watch file")
+		return
+	}
+	if last.Status == WatchStatusDone {
+		// already processed before restart
+		w.move(dir, fn, WatchStatusDone)
+		return
+	}
+
+	f := &util.File{Path: fn, Length: int64(len(data)), Data: data}
+	handled := false
+	w.Importer.handle(ctx, fn, f, func(r *ImportResult) {
+		switch r.Status {
+		case ImportStatusSkipped:
+		case ImportStatusFailed:
+			handled = true
+			wf.Status = WatchStatusFailed
+			if wf.Error == "" {
+				wf.Error = r.Error
+			}
+		default:
+			handled = true
+		}
+		l.Debug().Str("entry", r.Path).Str("status", string(r.Status)).Str("hash", r.Hash).Msg("watch import")
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	if !handled {
+		wf.Status = WatchStatusFailed
+		wf.Error = "unsupported file"
+	}
+
+	err = w.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   wf.ConflictColumns(),
+		DoUpdates: clause.AssignmentColumns([]string{"filename", "status", "error", "updated_at"}),
+	}).Create(&wf).Error
+	if err != nil {
+		l.Err(err).Msg("save watch file")
+		return
+	}
+	l.Info().Str("status", wf.Status).Str("error", wf.Error).Msg("watch file processed")
+	w.move(dir, fn, wf.Status)
+}
+
+// move file to sub dir of watch dir, keep relative path and avoid overwrite
+func (w *Watcher) move(dir string, fn string, to string) {
+	rel, err := filepath.Rel(dir, fn)
+	if err != nil {
+		rel = filepath.Base(fn)
+	}
+	dst := filepath.Join(dir, to, rel)
+	if err = os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		log.Err(err).Str("file", fn).Msg("make watch dir")
+		return
+	}
+	ext := filepath.Ext(dst)
+	base := strings.TrimSuffix(dst, ext)
+	for i := 1; ; i++ {
+		if _, err = os.Lstat(dst); err != nil {
+			break
+		}
+		dst = base + "." + strconv.Itoa(i) + ext
+	}
+	if err = os.Rename(fn, dst); err != nil {
+		log.Err(err).Str("file", fn).Str("to", dst).Msg("move watch file")
+	}
+}
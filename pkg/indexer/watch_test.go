@@ -0,0 +1,47 @@
+package indexer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+)
+
+func TestWatcher(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	w, err := NewWatcher(NewWatcherOptions{
+		Dirs:     []string{dir},
+		Importer: &Importer{Torrent: svc},
+		DB:       svc.DB,
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.torrent"), newTorrent(t, "a"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.torrent"), []byte("bad"), 0o644))
+
+	// first scan only record state
+	assert.NoError(t, w.Scan(ctx))
+	assert.FileExists(t, filepath.Join(dir, "sub", "a.torrent"))
+	assert.NoError(t, w.Scan(ctx))
+	assert.FileExists(t, filepath.Join(dir, WatchStatusDone, "sub", "a.torrent"))
+	assert.FileExists(t, filepath.Join(dir, WatchStatusFailed, "b.torrent"))
+
+	var n int64
+	assert.NoError(t, svc.DB.Model(models.MetaFile{}).Count(&n).Error)
+	assert.Equal(t, int64(1), n)
+	var wfs []models.WatchFile
+	assert.NoError(t, svc.DB.Order("id").Find(&wfs).Error)
+	assert.Len(t, wfs, 2)
+
+	// processed file dropped again is moved without conflict
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.torrent"), newTorrent(t, "a"), 0o644))
+	assert.NoError(t, w.Scan(ctx))
+	assert.NoError(t, w.Scan(ctx))
+	assert.FileExists(t, filepath.Join(dir, WatchStatusDone, "sub", "a.1.torrent"))
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	multihash "github.com/multiformats/go-multihash"
@@ -19,11 +21,23 @@ func (ih Hash) IsZero() bool {
 	return len(ih.Digest) == 0
 }
 
+func (ih Hash) IsV1() bool {
+	return ih.Name == "" || ih.Name == "sha1"
+}
+
 func (ih Hash) String() string {
-	if ih.Name == "" || ih.Name == "sha1" {
+	if ih.IsV1() {
 		return urnBtihPrefix + hex.EncodeToString(ih.Digest)
 	}
-	return urnBtmhPrefix + hex.EncodeToString(ih.Digest)
+	code, ok := multihash.Names[ih.Name]
+	if !ok {
+		return urnBtmhPrefix + hex.EncodeToString(ih.Digest)
+	}
+	mh, err := multihash.Encode(ih.Digest, code)
+	if err != nil {
+		return urnBtmhPrefix + hex.EncodeToString(ih.Digest)
+	}
+	return urnBtmhPrefix + hex.EncodeToString(mh)
 }
 
 func (ih Hash) Magent() Magnet {
@@ -36,13 +50,35 @@ func (ih Hash) HexHash() string {
 	return hex.EncodeToString(ih.Digest)
 }
 
+// IndexRange is a inclusive file index range of BEP 53 select only
+type IndexRange struct {
+	Start int
+	End   int
+}
+
+func (r IndexRange) String() string {
+	if r.Start == r.End {
+		return strconv.Itoa(r.Start)
+	}
+	return strconv.Itoa(r.Start) + "-" + strconv.Itoa(r.End)
+}
+
 // Magnet link components.
 type Magnet struct {
-	Hash        Hash       // From "xt"
-	Trackers    []string   // From "tr"
-	DisplayName string     // From "dn"
-	Peers       []string   // From "x.pe"
-	Params      url.Values // All other values, such as "as", "xs", etc
+	Hash              Hash         // From "xt", btih or btmh for v2 only torrent
+	HashV2            Hash         // From "xt", btmh of hybrid torrent
+	ExactTopics       []string     // From "xt", other than the info hashes, e.g. urn:sha1, urn:ed2k
+	DisplayName       string       // From "dn"
+	Length            int64        // From "xl"
+	Trackers          []string     // From "tr"
+	WebSeeds          []string     // From "ws", BEP 19
+	AcceptableSources []string     // From "as"
+	ExactSources      []string     // From "xs"
+	Keywords          []string     // From "kt"
+	ManifestTopic     string       // From "mt"
+	SelectOnly        []IndexRange // From "so", BEP 53
+	Peers             []string     // From "x.pe", BEP 9
+	Params            url.Values   // All other values
 }
 
 const (
@@ -50,32 +86,79 @@ const (
 	urnBtmhPrefix = "urn:btmh:"
 )
 
+// indexedParams can be numbered as "key.1", "key.2"
+var indexedParams = map[string]bool{"xt": true, "tr": true, "ws": true, "as": true, "xs": true, "kt": true}
+
+// String return canonical form, parameters in fixed order and others sorted by key
 func (m Magnet) String() string {
-	vs := make(url.Values, len(m.Params)+len(m.Trackers)+2)
-	for k, v := range m.Params {
-		vs[k] = append([]string(nil), v...)
+	// Transmission and Deluge both expect "urn:btih:" to be unescaped.
+	// Deluge wants it to be at the start of the magnet link.
+	sb := strings.Builder{}
+	sb.WriteString("magnet:?")
+	n := 0
+	add := func(k string, v string) {
+		if n > 0 {
+			sb.WriteByte('&')
+		}
+		n++
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(v)
+	}
+	if !m.Hash.IsZero() {
+		add("xt", m.Hash.String())
 	}
-	for _, tr := range m.Trackers {
-		vs.Add("tr", tr)
+	if !m.HashV2.IsZero() {
+		add("xt", m.HashV2.String())
 	}
-	for _, tr := range m.Peers {
-		vs.Add("x.pe", tr)
+	for _, v := range m.ExactTopics {
+		add("xt", strings.ReplaceAll(url.QueryEscape(v), "%3A", ":"))
 	}
 	if m.DisplayName != "" {
-		vs.Add("dn", m.DisplayName)
+		add("dn", url.QueryEscape(m.DisplayName))
 	}
-
-	// Transmission and Deluge both expect "urn:btih:" to be unescaped.
-	// Deluge wants it to be at the start of the magnet link.
-	// The InfoHash field is expected to be BitTorrent in this implementation.
-	u := url.URL{
-		Scheme:   "magnet",
-		RawQuery: "xt=" + m.Hash.String(),
+	if m.Length > 0 {
+		add("xl", strconv.FormatInt(m.Length, 10))
+	}
+	for _, v := range m.Trackers {
+		add("tr", url.QueryEscape(v))
+	}
+	for _, v := range m.WebSeeds {
+		add("ws", url.QueryEscape(v))
+	}
+	for _, v := range m.AcceptableSources {
+		add("as", url.QueryEscape(v))
+	}
+	for _, v := range m.ExactSources {
+		add("xs", url.QueryEscape(v))
+	}
+	if len(m.Keywords) > 0 {
+		kt := make([]string, 0, len(m.Keywords))
+		for _, v := range m.Keywords {
+			kt = append(kt, url.QueryEscape(v))
+		}
+		add("kt", strings.Join(kt, "+"))
+	}
+	if m.ManifestTopic != "" {
+		add("mt", url.QueryEscape(m.ManifestTopic))
+	}
+	if len(m.SelectOnly) > 0 {
+		so := make([]string, 0, len(m.SelectOnly))
+		for _, v := range m.SelectOnly {
+			so = append(so, v.String())
+		}
+		add("so", strings.Join(so, ","))
+	}
+	for _, v := range m.Peers {
+		add("x.pe", url.QueryEscape(v))
 	}
-	if len(vs) != 0 {
-		u.RawQuery += "&" + vs.Encode()
+	if len(m.Params) != 0 {
+		if n > 0 {
+			sb.WriteByte('&')
+		}
+		sb.WriteString(m.Params.Encode())
 	}
-	return u.String()
+	return sb.String()
 }
 
 // Parse parses Magnet-formatted URIs into a Magnet instance.
@@ -90,19 +173,76 @@ func Parse(uri string) (m Magnet, err error) {
 	}
 
 	q := u.Query()
-	xt := q.Get("xt")
-	if m.Hash, err = ParseHash(q.Get("xt")); err != nil {
-		err = fmt.Errorf("error parsing infohash %q: %s", xt, err)
+	mergeIndexed(q)
+
+	for _, xt := range q["xt"] {
+		var h Hash
+		if !strings.HasPrefix(xt, urnBtihPrefix) && !strings.HasPrefix(xt, urnBtmhPrefix) {
+			if xt != "" {
+				m.ExactTopics = append(m.ExactTopics, xt)
+			}
+			continue
+		}
+		if h, err = ParseHash(xt); err != nil {
+			err = fmt.Errorf("error parsing infohash %q: %s", xt, err)
+			return
+		}
+		switch {
+		case h.IsV1() && m.Hash.IsZero():
+			m.Hash = h
+		case !h.IsV1() && m.HashV2.IsZero():
+			m.HashV2 = h
+		default:
+			m.ExactTopics = append(m.ExactTopics, h.String())
+		}
+	}
+	delete(q, "xt")
+	if m.Hash.IsZero() {
+		// v2 only
+		m.Hash, m.HashV2 = m.HashV2, Hash{}
+	}
+	if m.Hash.IsZero() {
+		err = fmt.Errorf("missing info hash")
 		return
 	}
-	dropFirst(q, "xt")
 
-	m.DisplayName = q.Get("dn")
-	dropFirst(q, "dn")
+	m.DisplayName = takeFirst(q, "dn")
+
+	if xl := takeFirst(q, "xl"); xl != "" {
+		if m.Length, err = strconv.ParseInt(xl, 10, 64); err != nil || m.Length < 0 {
+			err = fmt.Errorf("invalid xl %q", xl)
+			return
+		}
+	}
 
 	m.Trackers = q["tr"]
 	delete(q, "tr")
 
+	m.WebSeeds = q["ws"]
+	delete(q, "ws")
+
+	m.AcceptableSources = q["as"]
+	delete(q, "as")
+
+	m.ExactSources = q["xs"]
+	delete(q, "xs")
+
+	for _, v := range q["kt"] {
+		m.Keywords = append(m.Keywords, strings.Fields(v)...)
+	}
+	delete(q, "kt")
+
+	m.ManifestTopic = takeFirst(q, "mt")
+
+	for _, v := range q["so"] {
+		var so []IndexRange
+		if so, err = ParseSelectOnly(v); err != nil {
+			return
+		}
+		m.SelectOnly = append(m.SelectOnly, so...)
+	}
+	delete(q, "so")
+
 	m.Peers = q["x.pe"]
 	delete(q, "x.pe")
 
@@ -114,38 +254,87 @@ func Parse(uri string) (m Magnet, err error) {
 	return
 }
 
+// ParseSelectOnly parse BEP 53 file index list, e.g. 0,2,4-6
+func ParseSelectOnly(s string) (out []IndexRange, err error) {
+	for _, v := range strings.Split(s, ",") {
+		if v == "" {
+			continue
+		}
+		var r IndexRange
+		start, end, found := strings.Cut(v, "-")
+		if r.Start, err = strconv.Atoi(start); err != nil || r.Start < 0 {
+			return nil, fmt.Errorf("invalid so %q", s)
+		}
+		r.End = r.Start
+		if found {
+			if r.End, err = strconv.Atoi(end); err != nil || r.End < r.Start {
+				return nil, fmt.Errorf("invalid so %q", s)
+			}
+		}
+		out = append(out, r)
+	}
+	return
+}
+
 func ParseHash(raw string) (ih Hash, err error) {
-	var n int
-	encoded := raw
 	switch {
-	case strings.HasPrefix(encoded, urnBtihPrefix):
-		encoded = encoded[len(urnBtihPrefix):]
-	case strings.HasPrefix(encoded, urnBtmhPrefix):
-		encoded = encoded[len(urnBtmhPrefix):]
-		var mh *multihash.DecodedMultihash
-		ih.Digest, err = hex.DecodeString(encoded)
+	case strings.HasPrefix(raw, urnBtmhPrefix):
+		return parseBtmh(raw[len(urnBtmhPrefix):])
+	case strings.HasPrefix(raw, urnBtihPrefix):
+		return parseBtih(raw[len(urnBtihPrefix):])
+	case len(raw) == 64: // v2 sha256
+		ih.Name = "sha2-256"
+		ih.Digest, err = hex.DecodeString(raw)
 		if err != nil {
-			err = fmt.Errorf("error hex decoding hash: %s", err)
-			return
+			err = fmt.Errorf("error decoding xt: %s", err)
 		}
+		return
+	default:
+		return parseBtih(raw)
+	}
+}
 
-		mh, err = multihash.Decode(ih.Digest)
-		if err != nil {
-			err = fmt.Errorf("error multihash decoding xt: %s", err)
-			return
-		}
-		ih.Name = mh.Name
-		ih.Digest = mh.Digest
+func parseBtmh(encoded string) (ih Hash, err error) {
+	var mh *multihash.DecodedMultihash
+	ih.Digest, err = hex.DecodeString(encoded)
+	if err != nil {
+		err = fmt.Errorf("error hex decoding hash: %s", err)
 		return
 	}
-	// info hash
+
+	mh, err = multihash.Decode(ih.Digest)
+	if err != nil {
+		err = fmt.Errorf("error multihash decoding xt: %s", err)
+		return
+	}
+	if _, ok := multihash.Names[mh.Name]; !ok {
+		err = fmt.Errorf("unsupported multihash code %#x", mh.Code)
+		return
+	}
+	if l, ok := multihash.DefaultLengths[mh.Code]; ok && l > 0 && l != mh.Length {
+		err = fmt.Errorf("invalid %v digest length %v", mh.Name, mh.Length)
+		return
+	}
+	ih.Name = mh.Name
+	ih.Digest = mh.Digest
+	if ih.Name == "sha1" {
+		ih.Name = ""
+	}
+	if ih.IsZero() {
+		err = fmt.Errorf("empty multihash digest")
+	}
+	return
+}
+
+func parseBtih(encoded string) (ih Hash, err error) {
+	var n int
 	switch len(encoded) {
 	case 40:
 		ih.Digest = make([]byte, 20)
-		n, err = hex.Decode(ih.Digest[:], []byte(encoded))
+		n, err = hex.Decode(ih.Digest, []byte(encoded))
 	case 32:
 		ih.Digest = make([]byte, 20)
-		n, err = base32.StdEncoding.Decode(ih.Digest[:], []byte(encoded))
+		n, err = base32.StdEncoding.Decode(ih.Digest, []byte(strings.ToUpper(encoded)))
 	default:
 		err = fmt.Errorf("unhandled xt parameter encoding (encoded length %d)", len(encoded))
 		return
@@ -158,12 +347,46 @@ func ParseHash(raw string) (ih Hash, err error) {
 	return
 }
 
-func dropFirst(vs url.Values, key string) {
-	sl := vs[key]
-	switch len(sl) {
-	case 0, 1:
-		vs.Del(key)
-	default:
-		vs[key] = sl[1:]
+// mergeIndexed merge numbered parameters, "tr.1" and "tr.2" to "tr"
+func mergeIndexed(vs url.Values) {
+	type indexed struct {
+		n      int
+		key    string
+		values []string
+	}
+	all := map[string][]indexed{}
+	for k, v := range vs {
+		key, num, found := strings.Cut(k, ".")
+		if !found || !indexedParams[key] {
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 0 {
+			continue
+		}
+		all[key] = append(all[key], indexed{n, k, v})
+		delete(vs, k)
+	}
+	for k, v := range all {
+		sort.Slice(v, func(i, j int) bool {
+			if v[i].n == v[j].n {
+				return v[i].key < v[j].key
+			}
+			return v[i].n < v[j].n
+		})
+		for _, vv := range v {
+			vs[k] = append(vs[k], vv.values...)
+		}
+	}
+}
+
+// takeFirst take first non-empty value of single value parameter, duplicates are dropped
+func takeFirst(vs url.Values, key string) string {
+	defer vs.Del(key)
+	for _, v := range vs[key] {
+		if v != "" {
+			return v
+		}
 	}
+	return ""
 }
@@ -16,3 +16,72 @@ func TestParse(t *testing.T) {
 	assert.Equal(t, raw, m.String())
 	assert.Equal(t, "", m.Hash.Name)
 }
+
+func TestParseBtmh(t *testing.T) {
+	raw := "magnet:?xt=urn:btmh:1220caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e"
+	m, err := Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "sha2-256", m.Hash.Name)
+	assert.Equal(t, 32, len(m.Hash.Digest))
+	assert.Equal(t, raw, m.String())
+
+	h, err := ParseHash("caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e")
+	assert.NoError(t, err)
+	assert.Equal(t, m.Hash, h)
+}
+
+func TestParseHybrid(t *testing.T) {
+	raw := "magnet:?xt=urn:btih:631a31dd0a46257d5078c0dee4e66e26f73e42ac&xt=urn:btmh:1220d8dd32ac93357c368556af3ac1d95c9d76bd0dff6fa9833ecdac3d53134efabb&dn=bittorrent-v1-v2-hybrid-test&xl=1024&tr=udp%3A%2F%2Ftracker.example.com%3A6969&ws=http%3A%2F%2Fexample.com%2Fa"
+	m, err := Parse(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "", m.Hash.Name)
+	assert.Equal(t, "sha2-256", m.HashV2.Name)
+	assert.Equal(t, int64(1024), m.Length)
+	assert.Equal(t, []string{"http://example.com/a"}, m.WebSeeds)
+	assert.Nil(t, m.Params)
+	assert.Equal(t, raw, m.String())
+}
+
+func TestParseFull(t *testing.T) {
+	m, err := Parse("magnet:?dn=test&xt=urn:sha1:ABC&xt.1=urn:btih:C12FE1C06BBA254A9DC9F519B335AA7C1367A88A&so=0,2,4-6&kt=a+b&kt=c&xs=http%3A%2F%2Fa%2Fb.torrent&as=http%3A%2F%2Fa%2Fb&mt=urn%3Asha1%3AX&tr.2=udp%3A%2F%2Fb&tr.1=udp%3A%2F%2Fa&x=1")
+	assert.NoError(t, err)
+	assert.Equal(t, "c12fe1c06bba254a9dc9f519b335aa7c1367a88a", m.Hash.HexHash())
+	assert.Equal(t, []string{"urn:sha1:ABC"}, m.ExactTopics)
+	assert.Equal(t, []string{"udp://a", "udp://b"}, m.Trackers)
+	assert.Equal(t, []string{"a", "b", "c"}, m.Keywords)
+	assert.Equal(t, []IndexRange{{0, 0}, {2, 2}, {4, 6}}, m.SelectOnly)
+	assert.Equal(t, "magnet:?xt=urn:btih:c12fe1c06bba254a9dc9f519b335aa7c1367a88a&xt=urn:sha1:ABC&dn=test&tr=udp%3A%2F%2Fa&tr=udp%3A%2F%2Fb&as=http%3A%2F%2Fa%2Fb&xs=http%3A%2F%2Fa%2Fb.torrent&kt=a+b+c&mt=urn%3Asha1%3AX&so=0,2,4-6&x=1", m.String())
+
+	for _, v := range []string{
+		"magnet:?dn=x",
+		"magnet:?xt=urn:btih:123",
+		"magnet:?xt=urn:btih:c12fe1c06bba254a9dc9f519b335aa7c1367a88a&xl=-1",
+		"magnet:?xt=urn:btih:c12fe1c06bba254a9dc9f519b335aa7c1367a88a&so=2-1",
+		"magnet:?xt=urn:btmh:1205caf1e1c30e",
+	} {
+		_, err = Parse(v)
+		assert.Error(t, err, v)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add("magnet:?xt=urn:btih:c12fe1c06bba254a9dc9f519b335aa7c1367a88a&dn=a+b&tr=udp%3A%2F%2Fa")
+	f.Add("magnet:?xt=urn:btmh:1220caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e&so=1-3,5&kt=x+y")
+	f.Add("magnet:?xt=urn:btih:YEX6DQDLUISUVHOJ6UM3GNNKPQJWPKEK&xt.1=urn:ed2k:abc&ws=http://a/&x.pe=1.2.3.4:5&foo=bar")
+	f.Fuzz(func(t *testing.T, s string) {
+		m, err := Parse(s)
+		if err != nil {
+			return
+		}
+		canonical := m.String()
+		m2, err := Parse(canonical)
+		if err != nil {
+			t.Fatalf("parse canonical %q of %q: %v", canonical, s, err)
+		}
+		if v := m2.String(); v != canonical {
+			t.Fatalf("not canonical %q != %q", v, canonical)
+		}
+	})
+}
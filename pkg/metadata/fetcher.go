@@ -0,0 +1,324 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/metainfo"
+	pp "github.com/xgfone/bt/peerprotocol"
+	"github.com/xgfone/bt/tracker"
+)
+
+// BlockSize metadata piece size, BEP 9
+const BlockSize = 16 * 1024
+
+// MaxMetadataSize limit the info dict size announced by peer
+const MaxMetadataSize = 64 * 1024 * 1024
+
+// localMetadataID extended message id of ut_metadata used by us
+const localMetadataID = 1
+
+var FetcherContextKey = util.ContextKey[*Fetcher]{Name: "metadata.Fetcher"}
+
+// DefaultFetcher used when no fetcher in context
+var DefaultFetcher = &Fetcher{}
+
+// Fetcher fetch info dict from peers by the extension protocol, BEP 9 & BEP 10
+type Fetcher struct {
+	PeerID      metainfo.Hash
+	Trackers    []string      // additional trackers for magnet without tracker
+	Timeout     time.Duration // timeout for single peer
+	Concurrency int
+}
+
+func (f *Fetcher) timeout() time.Duration {
+	if f.Timeout <= 0 {
+		return 15 * time.Second
+	}
+	return f.Timeout
+}
+
+func (f *Fetcher) peerID() metainfo.Hash {
+	if f.PeerID.IsZero() {
+		id := metainfo.NewRandomHash()
+		copy(id[:], "-TI0001-")
+		return id
+	}
+	return f.PeerID
+}
+
+// Fetch info dict of magnet from x.pe peers and peers returned by trackers
+func (f *Fetcher) Fetch(ctx context.Context, m magnet.Magnet) (info []byte, err error) {
+	if m.Hash.IsZero() {
+		return nil, errors.New("magnet has no hash")
+	}
+	ih := WireHash(m.Hash)
+	id := f.peerID()
+
+	peers := append([]string(nil), m.Peers...)
+	trackers := append(append([]string(nil), m.Trackers...), f.Trackers...)
+	if len(trackers) > 0 {
+		tctx, cancel := context.WithTimeout(ctx, f.timeout())
+		results := tracker.GetPeers(tctx, id, ih, trackers)
+		cancel()
+		for _, v := range results {
+			if v.Error != nil {
+				log.Debug().Err(v.Error).Str("tracker", v.Tracker).Msg("get peers")
+				continue
+			}
+			for _, addr := range v.Resp.Addresses {
+				peers = append(peers, addr.String())
+			}
+		}
+	}
+	peers = uniqueStrings(peers)
+	if len(peers) == 0 {
+		return nil, errors.Errorf("no peer found for %s", m.Hash)
+	}
+
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	addrs := make(chan string)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range addrs {
+				data, err := f.FetchPeer(ctx, addr, m.Hash)
+				mu.Lock()
+				if err == nil && info == nil {
+					info = data
+					cancel()
+				} else if err != nil {
+					log.Debug().Err(err).Str("peer", addr).Msg("fetch metadata")
+					errs = append(errs, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+LOOP:
+	for _, addr := range peers {
+		select {
+		case addrs <- addr:
+		case <-ctx.Done():
+			break LOOP
+		}
+	}
+	close(addrs)
+	wg.Wait()
+
+	if info != nil {
+		return info, nil
+	}
+	if len(errs) > 0 {
+		err = errs[len(errs)-1]
+	} else {
+		err = ctx.Err()
+	}
+	return nil, errors.Wrapf(err, "fetch metadata from %v peers", len(peers))
+}
+
+// FetchPeer fetch info dict from single peer and verify against the hash
+func (f *Fetcher) FetchPeer(ctx context.Context, addr string, h magnet.Hash) (info []byte, err error) {
+	timeout := f.timeout()
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	pc := pp.NewPeerConn(conn, f.peerID(), WireHash(h))
+	pc.Timeout = timeout
+	pc.MaxLength = BlockSize * 4
+	pc.ExtBits[7-pp.ExtensionBitExtended/8] |= 1 << (pp.ExtensionBitExtended % 8)
+	pc.OnWriteMsg = writeMsg
+
+	if err = pc.Handshake(); err != nil {
+		return nil, errors.Wrap(err, "handshake")
+	}
+	if !pc.PeerExtBits.IsSupportExtended() {
+		return nil, pp.ErrNotSupportExtended
+	}
+	err = pc.SendExtHandshakeMsg(pp.ExtendedHandshakeMsg{
+		M: map[string]uint8{pp.ExtendedMessageNameMetadata: localMetadataID},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "send extended handshake")
+	}
+
+	var (
+		received []bool
+		pending  int
+	)
+	for {
+		var msg pp.Message
+		if msg, err = pc.ReadMsg(); err != nil {
+			return nil, errors.Wrap(err, "read message")
+		}
+		if msg.Keepalive || msg.Type != pp.MTypeExtended {
+			continue
+		}
+
+		switch msg.ExtendedID {
+		case pp.ExtendedIDHandshake:
+			var ehm pp.ExtendedHandshakeMsg
+			if err = ehm.Decode(msg.ExtendedPayload); err != nil {
+				return nil, errors.Wrap(err, "decode extended handshake")
+			}
+			remoteID, ok := ehm.M[pp.ExtendedMessageNameMetadata]
+			if !ok || remoteID == 0 {
+				return nil, errors.New("peer not support ut_metadata")
+			}
+			if ehm.MetadataSize <= 0 || ehm.MetadataSize > MaxMetadataSize {
+				return nil, errors.Errorf("invalid metadata size %v", ehm.MetadataSize)
+			}
+			if info != nil {
+				continue
+			}
+			info = make([]byte, ehm.MetadataSize)
+			pending = (ehm.MetadataSize + BlockSize - 1) / BlockSize
+			received = make([]bool, pending)
+			for i := 0; i < pending; i++ {
+				var payload []byte
+				payload, err = pp.UtMetadataExtendedMsg{
+					MsgType: pp.UtMetadataExtendedMsgTypeRequest,
+					Piece:   i,
+				}.EncodeToBytes()
+				if err == nil {
+					err = pc.SendExtMsg(remoteID, payload)
+				}
+				if err != nil {
+					return nil, errors.Wrap(err, "request metadata")
+				}
+			}
+		case localMetadataID:
+			if info == nil {
+				return nil, errors.New("metadata before extended handshake")
+			}
+			var um pp.UtMetadataExtendedMsg
+			if um, err = msg.UtMetadataExtendedMsg(); err != nil {
+				return nil, errors.Wrap(err, "decode ut_metadata")
+			}
+			switch um.MsgType {
+			case pp.UtMetadataExtendedMsgTypeReject:
+				return nil, errors.Errorf("metadata piece %v rejected", um.Piece)
+			case pp.UtMetadataExtendedMsgTypeData:
+			default:
+				continue
+			}
+			if um.Piece < 0 || um.Piece >= len(received) {
+				return nil, errors.Errorf("invalid metadata piece %v", um.Piece)
+			}
+			offset := um.Piece * BlockSize
+			size := len(info) - offset
+			if size > BlockSize {
+				size = BlockSize
+			}
+			if len(um.Data) != size {
+				return nil, errors.Errorf("invalid metadata piece %v size %v", um.Piece, len(um.Data))
+			}
+			if !received[um.Piece] {
+				copy(info[offset:], um.Data)
+				received[um.Piece] = true
+				pending--
+			}
+			if pending == 0 {
+				if err = Verify(h, info); err != nil {
+					return nil, err
+				}
+				return info, nil
+			}
+		}
+	}
+}
+
+// writeMsg fix extended message encoding, peerprotocol drops the extended payload
+func writeMsg(pc *pp.PeerConn, m pp.Message) (err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 128))
+	if m.Keepalive || m.Type != pp.MTypeExtended {
+		if err = m.Encode(buf); err != nil {
+			return
+		}
+	} else {
+		buf.Grow(6 + len(m.ExtendedPayload))
+		_ = binary.Write(buf, binary.BigEndian, uint32(2+len(m.ExtendedPayload)))
+		buf.WriteByte(byte(m.Type))
+		buf.WriteByte(m.ExtendedID)
+		buf.Write(m.ExtendedPayload)
+	}
+	if pc.Timeout > 0 {
+		_ = pc.Conn.SetWriteDeadline(time.Now().Add(pc.Timeout))
+	}
+	_, err = pc.Conn.Write(buf.Bytes())
+	return
+}
+
+// WireHash is the 20 bytes hash used in peer wire protocol, v2 hash is truncated
+func WireHash(h magnet.Hash) metainfo.Hash {
+	return metainfo.NewHash(h.Digest[:metainfo.HashSize])
+}
+
+// Verify info dict match the info hash
+func Verify(h magnet.Hash, info []byte) error {
+	var sum []byte
+	switch h.Name {
+	case "", "sha1":
+		v := sha1.Sum(info)
+		sum = v[:]
+	case "sha2-256":
+		v := sha256.Sum256(info)
+		sum = v[:]
+	default:
+		return errors.Errorf("unsupported hash %q", h.Name)
+	}
+	if !bytes.Equal(sum, h.Digest) {
+		return errors.Errorf("info hash mismatch: expected %s", h)
+	}
+	return nil
+}
+
+func uniqueStrings(s []string) []string {
+	seen := make(map[string]struct{}, len(s))
+	out := s[:0]
+	for _, v := range s {
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/xgfone/bt/metainfo"
+	pp "github.com/xgfone/bt/peerprotocol"
+)
+
+// servePeer is a stand-in peer which only serve the metadata
+func servePeer(t *testing.T, ln net.Listener, info []byte) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			pc := pp.NewPeerConn(conn, metainfo.NewRandomHash(), metainfo.Hash{})
+			pc.Timeout = 5 * time.Second
+			pc.ExtBits[7-pp.ExtensionBitExtended/8] |= 1 << (pp.ExtensionBitExtended % 8)
+			pc.OnWriteMsg = writeMsg
+			if err := pc.Handshake(); err != nil {
+				t.Log("handshake", err)
+				return
+			}
+			err := pc.SendExtHandshakeMsg(pp.ExtendedHandshakeMsg{
+				M:            map[string]uint8{pp.ExtendedMessageNameMetadata: 3},
+				MetadataSize: len(info),
+			})
+			if err != nil {
+				return
+			}
+			var remote uint8
+			for {
+				msg, err := pc.ReadMsg()
+				if err != nil {
+					return
+				}
+				if msg.Type != pp.MTypeExtended {
+					continue
+				}
+				switch msg.ExtendedID {
+				case pp.ExtendedIDHandshake:
+					var ehm pp.ExtendedHandshakeMsg
+					if err = ehm.Decode(msg.ExtendedPayload); err != nil {
+						return
+					}
+					remote = ehm.M[pp.ExtendedMessageNameMetadata]
+				case 3:
+					um, err := msg.UtMetadataExtendedMsg()
+					if err != nil {
+						return
+					}
+					end := (um.Piece + 1) * BlockSize
+					if end > len(info) {
+						end = len(info)
+					}
+					payload, _ := pp.UtMetadataExtendedMsg{
+						MsgType:   pp.UtMetadataExtendedMsgTypeData,
+						Piece:     um.Piece,
+						TotalSize: len(info),
+						Data:      info[um.Piece*BlockSize : end],
+					}.EncodeToBytes()
+					if err = pc.SendExtMsg(remote, payload); err != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+}
+
+func TestFetcher(t *testing.T) {
+	// multiple metadata pieces
+	info := append([]byte("d4:name4:test6:pieces"), bytes.Repeat([]byte("x"), BlockSize*2)...)
+	info = append(info, 'e')
+	sum := sha1.Sum(info)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+	go servePeer(t, ln, info)
+
+	f := &Fetcher{Timeout: 5 * time.Second}
+	m := magnet.Magnet{
+		Hash:  magnet.Hash{Digest: sum[:]},
+		Peers: []string{ln.Addr().String()},
+	}
+	out, err := f.Fetch(context.Background(), m)
+	assert.NoError(t, err)
+	assert.Equal(t, info, out)
+
+	m.Hash.Digest = bytes.Repeat([]byte{1}, 20)
+	_, err = f.Fetch(context.Background(), m)
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+	"github.com/blugelabs/bluge/search/aggregations"
+)
+
+// facet name, value of facet is a query clause when prefixed by name, e.g. ext:mkv size:1GB..4GB
+const (
+	FacetExt    = QueryFieldExt
+	FacetSize   = QueryFieldSize
+	FacetYear   = QueryFieldDate
+	FacetDomain = QueryFieldSite
+)
+
+// FacetTermSize is the max values of terms facet
+const FacetTermSize = 20
+
+type Facet struct {
+	Name   string
+	Values []*FacetValue
+}
+
+type FacetValue struct {
+	Value string
+	Count int
+}
+
+// sizeBuckets histogram of total size, name is the size query.
+// range query is inclusive, bucket include the high bound to count same as the query, size at bound is in both buckets.
+var sizeBuckets = []struct {
+	name      string
+	low, high float64
+}{
+	{"..100MB", bluge.MinNumeric, 100e6},
+	{"100MB..1GB", 100e6, 1e9},
+	{"1GB..4GB", 1e9, 4e9},
+	{"4GB..10GB", 4e9, 10e9},
+	{"10GB..50GB", 10e9, 50e9},
+	{"50GB..", 50e9, bluge.MaxNumeric},
+}
+
+func addFacetAggregations(r *bluge.TopNSearch) {
+	r.AddAggregation(FacetExt, aggregations.NewTermsAggregation(search.Field(docFieldExt), FacetTermSize))
+	r.AddAggregation(FacetYear, aggregations.NewTermsAggregation(search.Field(docFieldYear), FacetTermSize))
+	r.AddAggregation(FacetDomain, aggregations.NewTermsAggregation(search.Field(docFieldDomain), FacetTermSize))
+	sizes := aggregations.Ranges(search.Field(docFieldSize))
+	for _, v := range sizeBuckets {
+		sizes.AddRange(aggregations.NamedRange(v.name, v.low, math.Nextafter(v.high, bluge.MaxNumeric)))
+	}
+	r.AddAggregation(FacetSize, sizes)
+}
+
+// facets collect non empty buckets, years are in descending order, terms are ordered by count then value
+func facets(agg *search.Bucket) []*Facet {
+	var out []*Facet
+	for _, name := range []string{FacetExt, FacetSize, FacetYear, FacetDomain} {
+		f := &Facet{Name: name}
+		for _, b := range agg.Buckets(name) {
+			if b.Count() > 0 {
+				f.Values = append(f.Values, &FacetValue{Value: b.Name(), Count: int(b.Count())})
+			}
+		}
+		switch name {
+		case FacetYear:
+			sort.Slice(f.Values, func(i, j int) bool {
+				return f.Values[i].Value > f.Values[j].Value
+			})
+		case FacetExt, FacetDomain:
+			sort.SliceStable(f.Values, func(i, j int) bool {
+				a, b := f.Values[i], f.Values[j]
+				if a.Count != b.Count {
+					return a.Count > b.Count
+				}
+				return a.Value < b.Value
+			})
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// facetFilter compile selected facet values, values of same facet are any of, facets are all of
+func facetFilter(filters []string) (bluge.Query, error) {
+	var names []string
+	byName := map[string][]bluge.Query{}
+	for _, v := range filters {
+		q, err := ParseQuery(v)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.ToLower(strings.TrimSpace(v))
+		if i := strings.IndexByte(name, ':'); i > 0 {
+			name = name[:i]
+		}
+		if byName[name] == nil {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], q)
+	}
+	q := bluge.NewBooleanQuery()
+	for _, name := range names {
+		q.AddMust(bluge.NewBooleanQuery().AddShould(byName[name]...).SetMinShould(1))
+	}
+	return q, nil
+}
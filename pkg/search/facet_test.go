@@ -0,0 +1,53 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchFacets(t *testing.T) {
+	s, err := NewService(NewServiceOptions{DataDir: t.TempDir(), Write: true})
+	assert.NoError(t, err)
+	defer s.Close()
+
+	year := func(y int) time.Time {
+		return time.Date(y, 6, 1, 0, 0, 0, 0, time.UTC)
+	}
+	assert.NoError(t, s.IndexTorrent(context.Background(), []*TorrentDocument{
+		{ID: "a", TorrentFileName: "show s01", Size: 2e9, CreatedAt: year(2021), Exts: []string{"mkv"}, Domain: "www.a.com"},
+		{ID: "b", TorrentFileName: "show s02", Size: 4e9, CreatedAt: year(2022), Exts: []string{"mkv", "srt"}, Domain: "b.com"},
+		{ID: "c", TorrentFileName: "show s03", Size: 50e6, CreatedAt: year(2022), Exts: []string{"mp4"}, Domain: "a.com"},
+	}))
+
+	r, err := s.SearchTorrent(context.Background(), &SearchRequest{QueryString: "show", Facets: true})
+	assert.NoError(t, err)
+	values := map[string][]FacetValue{}
+	for _, f := range r.Facets {
+		for _, v := range f.Values {
+			values[f.Name] = append(values[f.Name], *v)
+		}
+	}
+	assert.Equal(t, []FacetValue{{"mkv", 2}, {"mp4", 1}, {"srt", 1}}, values[FacetExt])
+	assert.Equal(t, []FacetValue{{"..100MB", 1}, {"1GB..4GB", 2}, {"4GB..10GB", 1}}, values[FacetSize])
+	assert.Equal(t, []FacetValue{{"2022", 2}, {"2021", 1}}, values[FacetYear])
+	assert.Equal(t, []FacetValue{{"a.com", 2}, {"b.com", 1}}, values[FacetDomain])
+
+	for _, test := range []struct {
+		filters []string
+		count   int
+	}{
+		{[]string{"ext:mkv"}, 2},
+		{[]string{"ext:mkv", "ext:mp4"}, 3},
+		{[]string{"ext:mkv", "date:2022"}, 1},
+		{[]string{"site:a.com", "size:..100MB"}, 1},
+		{[]string{"size:1GB..4GB"}, 2},
+		{[]string{"size:4GB..10GB"}, 1},
+	} {
+		r, err := s.SearchTorrent(context.Background(), &SearchRequest{QueryString: "show", Filters: test.filters})
+		assert.NoError(t, err)
+		assert.Equal(t, test.count, r.Count, test.filters)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/blugelabs/bluge/analysis"
 	"github.com/blugelabs/bluge/analysis/char"
+	"github.com/blugelabs/bluge/analysis/lang/cjk"
 	"github.com/blugelabs/bluge/analysis/token"
 	"github.com/blugelabs/bluge/analysis/tokenizer"
 	"github.com/longbridgeapp/opencc"
@@ -46,7 +48,10 @@ func (c *ConvertFilter) Filter(input analysis.TokenStream) analysis.TokenStream
 	return input
 }
 
-var filenameAnalyzer *analysis.Analyzer
+var (
+	filenameAnalyzer      *analysis.Analyzer // index han and kana as unigrams and bigrams
+	filenameQueryAnalyzer *analysis.Analyzer // bigrams only, so 三体 match the bigram instead of any of 三 or 体
+)
 
 func init() {
 	filter, err := NewConvertFilter()
@@ -54,15 +59,88 @@ func init() {
 		panic(err)
 	}
 
-	filenameAnalyzer = &analysis.Analyzer{
+	filenameAnalyzer = newFilenameAnalyzer(filter, true)
+	filenameQueryAnalyzer = newFilenameAnalyzer(filter, false)
+}
+
+// newFilenameAnalyzer split by unicode word, han and kana are split to bigrams as they are written without space
+func newFilenameAnalyzer(filter analysis.TokenFilter, unigram bool) *analysis.Analyzer {
+	return &analysis.Analyzer{
 		CharFilters: []analysis.CharFilter{
-			// UnicodeTokenizer 不会分 .
-			char.NewRegexpCharFilter(regexp.MustCompile(`[.,_]+`), []byte(" ")),
+			// UnicodeTokenizer 不会分 ., replace per byte to keep offsets for highlight
+			char.NewRegexpCharFilter(regexp.MustCompile(`[.,_]`), []byte(" ")),
 		},
 		Tokenizer: tokenizer.NewUnicodeTokenizer(),
 		TokenFilters: []analysis.TokenFilter{
+			// half width katakana to full width
+			cjk.NewWidthFilter(),
 			token.NewLowerCaseFilter(),
 			filter,
+			&BigramFilter{Unigram: unigram},
 		},
 	}
 }
+
+// BigramFilter split adjacent ideographic tokens to overlapped bigrams, single character is kept as unigram.
+// cjk.BigramFilter lost the last unigram of run followed by other tokens.
+type BigramFilter struct {
+	Unigram bool // also output unigrams at the position of bigram, single character can be matched
+}
+
+func (f *BigramFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	out := make(analysis.TokenStream, 0, len(input)*2)
+	var run []*analysis.Token
+	flush := func() {
+		for i, t := range run {
+			if f.Unigram || len(run) == 1 {
+				t.PositionIncr = 1
+				out = append(out, t)
+			}
+			if i+1 < len(run) {
+				next := run[i+1]
+				b := &analysis.Token{
+					Term:         append(append(make([]byte, 0, len(t.Term)+len(next.Term)), t.Term...), next.Term...),
+					Start:        t.Start,
+					End:          next.End,
+					PositionIncr: 1,
+					Type:         analysis.Double,
+				}
+				if f.Unigram {
+					b.PositionIncr = 0
+				}
+				out = append(out, b)
+			}
+		}
+		run = run[:0]
+	}
+	for _, t := range input {
+		if t.Type != analysis.Ideographic {
+			flush()
+			out = append(out, t)
+			continue
+		}
+		runes := splitRunes(t)
+		if len(run) > 0 && run[len(run)-1].End != runes[0].Start {
+			flush()
+		}
+		run = append(run, runes...)
+	}
+	flush()
+	return out
+}
+
+// splitRunes split token to single character tokens, offsets are kept if term is not converted to different length
+func splitRunes(t *analysis.Token) []*analysis.Token {
+	out := make([]*analysis.Token, 0, utf8.RuneCount(t.Term))
+	exact := len(t.Term) == t.End-t.Start
+	for i := 0; i < len(t.Term); {
+		_, n := utf8.DecodeRune(t.Term[i:])
+		v := &analysis.Token{Term: t.Term[i : i+n], Start: t.Start, End: t.End, Type: analysis.Single}
+		if exact {
+			v.Start, v.End = t.Start+i, t.Start+i+n
+		}
+		out = append(out, v)
+		i += n
+	}
+	return out
+}
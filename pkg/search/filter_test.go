@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchCJK(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewService(NewServiceOptions{DataDir: dir, Write: true, Pinyin: true})
+	assert.NoError(t, err)
+	assert.False(t, s.Outdated())
+
+	assert.NoError(t, s.IndexTorrent(context.Background(), []*TorrentDocument{
+		{ID: "a", TorrentFileName: "三体第一季.S01E01.1080p"},
+		{ID: "b", TorrentFileName: "三國演義"},
+		{ID: "c", TorrentFileName: "進撃の巨人 ｼｰｽﾞﾝ2"},
+		{ID: "d", TorrentFileName: "体育第一"},
+		{ID: "e", TorrentFileName: "满江红"},
+	}))
+
+	for _, test := range []struct {
+		q   string
+		ids []string
+	}{
+		{"三体", []string{"a"}},
+		{"第一季", []string{"a"}},
+		{"三", []string{"a", "b"}},
+		{"三国", []string{"b"}},
+		{`"体第一"`, []string{"a"}},
+		{"巨人", []string{"c"}},
+		{"シーズン", []string{"c"}},
+		{"santi", []string{"a"}},
+		{`"san ti"`, []string{"a"}},
+		{"stdyj", []string{"a"}},
+		{"sanguoyanyi", []string{"b"}},
+		{"tiyu", []string{"d"}},
+		{"manjiang", []string{"e"}},
+		{"mjh", []string{"e"}},
+		// single syllable is english word
+		{"man", nil},
+	} {
+		r, err := s.SearchTorrent(context.Background(), &SearchRequest{QueryString: test.q})
+		if !assert.NoError(t, err, test.q) {
+			continue
+		}
+		var ids []string
+		for _, v := range r.Docs {
+			ids = append(ids, v.ID)
+		}
+		sort.Strings(ids)
+		assert.Equal(t, test.ids, ids, test.q)
+	}
+	assert.NoError(t, s.Close())
+
+	// analysis options changed
+	s, err = NewService(NewServiceOptions{DataDir: dir, Write: true})
+	assert.NoError(t, err)
+	assert.True(t, s.Outdated())
+	assert.NoError(t, s.MarkIndexed())
+	assert.NoError(t, s.Close())
+
+	s, err = NewService(NewServiceOptions{DataDir: dir, Write: true})
+	assert.NoError(t, err)
+	assert.False(t, s.Outdated())
+	assert.NoError(t, s.Close())
+
+	// reader ignore pinyin option but not version
+	s, err = NewService(NewServiceOptions{DataDir: dir, Pinyin: true})
+	assert.NoError(t, err)
+	assert.False(t, s.Outdated())
+	assert.NoError(t, s.Close())
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "torrent.json"), []byte(`{"version":1}`), 0o644))
+	s, err = NewService(NewServiceOptions{DataDir: dir})
+	assert.NoError(t, err)
+	assert.True(t, s.Outdated())
+	assert.NoError(t, s.Close())
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/blugelabs/bluge/search"
+)
+
+// MaxDocumentPaths is the max file paths indexed of a torrent, keep index size of huge torrent reasonable
+const MaxDocumentPaths = 1000
+
+// MaxFileMatches is the max matched files returned of a document
+const MaxFileMatches = 10
+
+type FileMatch struct {
+	Path      string
+	Highlight string // html escaped path with matched terms in <mark>
+}
+
+// documentPaths join paths by line, paths exceed MaxDocumentPaths are dropped
+func documentPaths(paths []string) string {
+	if len(paths) > MaxDocumentPaths {
+		paths = paths[:MaxDocumentPaths]
+	}
+	var sb strings.Builder
+	for _, v := range paths {
+		v = strings.TrimSpace(strings.ReplaceAll(v, "\n", " "))
+		if v == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(v)
+	}
+	return sb.String()
+}
+
+// matchFiles find the lines of joined paths contain term locations, in order of paths
+func matchFiles(paths string, tlm search.TermLocationMap) []*FileMatch {
+	var locs search.Locations
+	for _, v := range tlm {
+		for _, l := range v {
+			if l.Start >= 0 && l.Start < l.End && l.End <= len(paths) {
+				locs = append(locs, l)
+			}
+		}
+	}
+	sort.Slice(locs, func(i, j int) bool {
+		if locs[i].Start != locs[j].Start {
+			return locs[i].Start < locs[j].Start
+		}
+		return locs[i].End < locs[j].End
+	})
+
+	var out []*FileMatch
+	for len(locs) > 0 && len(out) < MaxFileMatches {
+		start := strings.LastIndexByte(paths[:locs[0].Start], '\n') + 1
+		end := len(paths)
+		if i := strings.IndexByte(paths[start:], '\n'); i >= 0 {
+			end = start + i
+		}
+		n := 1
+		for n < len(locs) && locs[n].Start < end {
+			n++
+		}
+		out = append(out, &FileMatch{
+			Path:      paths[start:end],
+			Highlight: highlightLine(paths, start, end, locs[:n]),
+		})
+		locs = locs[n:]
+	}
+	return out
+}
+
+// highlightLine mark sorted locations of line, overlapped or adjacent locations are merged
+func highlightLine(s string, start, end int, locs search.Locations) string {
+	var sb strings.Builder
+	cur := start
+	for i := 0; i < len(locs); {
+		a, b := locs[i].Start, locs[i].End
+		for i++; i < len(locs) && locs[i].Start <= b; i++ {
+			if locs[i].End > b {
+				b = locs[i].End
+			}
+		}
+		if b > end {
+			b = end
+		}
+		if a < cur {
+			a = cur
+		}
+		if a >= b {
+			continue
+		}
+		sb.WriteString(html.EscapeString(s[cur:a]))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(s[a:b]))
+		sb.WriteString("</mark>")
+		cur = b
+	}
+	sb.WriteString(html.EscapeString(s[cur:end]))
+	return strings.ToValidUTF8(sb.String(), "")
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPath(t *testing.T) {
+	s, err := NewService(NewServiceOptions{DataDir: t.TempDir(), Write: true})
+	assert.NoError(t, err)
+	defer s.Close()
+
+	var many []string
+	for i := 0; i < MaxDocumentPaths+10; i++ {
+		many = append(many, fmt.Sprintf("Pack/track%04d.flac", i))
+	}
+	assert.NoError(t, s.IndexTorrent(context.Background(), []*TorrentDocument{
+		{ID: "a", TorrentFileName: "Show Season 1", Paths: []string{
+			"Show S01/Show.S01E01.Pilot.mkv",
+			"Show S01/Show.S01E02.<Dragon> & Sword.mkv",
+			"Show S01/Extras/Making of Dragon.mp4",
+		}},
+		{ID: "b", TorrentFileName: "Music Pack", Paths: many},
+	}))
+
+	search := func(q string) *SearchResponse {
+		r, err := s.SearchTorrent(context.Background(), &SearchRequest{QueryString: q})
+		assert.NoError(t, err, q)
+		return r
+	}
+
+	r := search("dragon")
+	if assert.Len(t, r.Docs, 1) {
+		assert.Equal(t, []*FileMatch{
+			{Path: "Show S01/Show.S01E02.<Dragon> & Sword.mkv", Highlight: "Show S01/Show.S01E02.&lt;<mark>Dragon</mark>&gt; &amp; Sword.mkv"},
+			{Path: "Show S01/Extras/Making of Dragon.mp4", Highlight: "Show S01/Extras/Making of <mark>Dragon</mark>.mp4"},
+		}, r.Docs[0].Files)
+	}
+
+	r = search(`path:"s01e01 pilot"`)
+	if assert.Len(t, r.Docs, 1) {
+		assert.Equal(t, "Show S01/Show.<mark>S01E01</mark>.<mark>Pilot</mark>.mkv", r.Docs[0].Files[0].Highlight)
+	}
+	assert.Empty(t, search("name:dragon").Docs)
+
+	// name match has no file
+	r = search("season")
+	if assert.Len(t, r.Docs, 1) {
+		assert.Empty(t, r.Docs[0].Files)
+	}
+
+	r = search("flac")
+	if assert.Len(t, r.Docs, 1) {
+		assert.Len(t, r.Docs[0].Files, MaxFileMatches)
+	}
+	assert.Len(t, search(fmt.Sprintf("track%04d", MaxDocumentPaths-1)).Docs, 1)
+	assert.Empty(t, search(fmt.Sprintf("track%04d", MaxDocumentPaths)).Docs)
+}
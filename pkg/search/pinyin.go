@@ -0,0 +1,193 @@
+package search
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/blugelabs/bluge/analysis/lang/cjk"
+	"github.com/blugelabs/bluge/analysis/token"
+	"github.com/blugelabs/bluge/analysis/tokenizer"
+	"github.com/mozillazg/go-pinyin"
+	"github.com/samber/lo"
+)
+
+var (
+	pinyinAnalyzer      *analysis.Analyzer // han to pinyin syllables, adjacent syllables, joined run and initials
+	pinyinQueryAnalyzer *analysis.Analyzer // latin letters only
+)
+
+func init() {
+	pinyinAnalyzer = &analysis.Analyzer{
+		Tokenizer: tokenizer.NewUnicodeTokenizer(),
+		TokenFilters: []analysis.TokenFilter{
+			cjk.NewWidthFilter(),
+			&PinyinFilter{},
+		},
+	}
+	pinyinQueryAnalyzer = &analysis.Analyzer{
+		Tokenizer: tokenizer.NewUnicodeTokenizer(),
+		TokenFilters: []analysis.TokenFilter{
+			token.NewLowerCaseFilter(),
+			pinyinTermFilter{},
+		},
+	}
+}
+
+// PinyinFilter convert run of han characters to pinyin, other tokens are dropped.
+// 三体 is indexed as san, santi, ti, longer run also has joined syllables and prefixed initials, e.g. santidiyiji and _stdyj for 三体第一季.
+type PinyinFilter struct{}
+
+func (f *PinyinFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	out := make(analysis.TokenStream, 0, len(input)*2)
+	var run []*analysis.Token
+	flush := func() {
+		var full, initials []byte
+		for _, t := range run {
+			full = append(full, t.Term...)
+			initials = append(initials, t.Term[0])
+		}
+		for i, t := range run {
+			out = append(out, t)
+			if i+1 < len(run) {
+				out = append(out, &analysis.Token{
+					Term:  append(append([]byte{}, t.Term...), run[i+1].Term...),
+					Start: t.Start, End: run[i+1].End, Type: analysis.Double,
+				})
+			}
+			// initials of short run are too ambiguous
+			if i == 0 && len(run) > 2 {
+				last := run[len(run)-1].End
+				out = append(out,
+					&analysis.Token{Term: full, Start: t.Start, End: last, Type: analysis.Shingle},
+					&analysis.Token{Term: append([]byte(pinyinInitialsPrefix), initials...), Start: t.Start, End: last, Type: analysis.Shingle},
+				)
+			}
+		}
+		run = run[:0]
+	}
+	for _, t := range input {
+		for _, v := range splitRunes(t) {
+			r := []rune(string(v.Term))[0]
+			var py []string
+			if unicode.Is(unicode.Han, r) {
+				py = pinyin.SinglePinyin(r, pinyinArgs)
+			}
+			if len(py) == 0 || py[0] == "" {
+				flush()
+				continue
+			}
+			if len(run) > 0 && run[len(run)-1].End != v.Start {
+				flush()
+			}
+			run = append(run, &analysis.Token{Term: []byte(py[0]), Start: v.Start, End: v.End, PositionIncr: 1, Type: analysis.AlphaNumeric})
+		}
+	}
+	flush()
+	return out
+}
+
+var pinyinArgs = pinyin.NewArgs()
+
+// pinyinInitialsPrefix mark initials term, initials only match query of consonants
+const pinyinInitialsPrefix = "_"
+
+// pinyinTermFilter keep latin letter terms can be pinyin, han in query is matched by name fields.
+// consonants match initials, other terms need two or more syllables in total, single syllable is mostly english word.
+type pinyinTermFilter struct{}
+
+func (pinyinTermFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	out := input[:0]
+	var syllable *analysis.Token
+	n := 0
+	for _, t := range input {
+		s := string(t.Term)
+		switch {
+		case !isPinyin(s):
+		case isPinyinInitials(s):
+			t.Term = append([]byte(pinyinInitialsPrefix), t.Term...)
+			out = append(out, t)
+		default:
+			if c := pinyinSyllables(s); c > 0 {
+				n += c
+				syllable = t
+				out = append(out, t)
+			}
+		}
+	}
+	if n == 1 {
+		out = lo.Filter(out, func(t *analysis.Token, _ int) bool {
+			return t != syllable
+		})
+	}
+	return out
+}
+
+// isPinyinInitials check s is initials of indexed run, letters are first letter of pinyin initials
+func isPinyinInitials(s string) bool {
+	return len(s) > 2 && strings.Trim(s, "bpmfdtnlgkhjqxrzcsyw") == ""
+}
+
+var (
+	pinyinSyllableOnce sync.Once
+	pinyinSyllableSet  map[string]bool
+)
+
+// pinyinSyllables return max syllables s can split into, 0 if s is not pinyin.
+// syllables without vowel, e.g. m and ng, are excluded.
+func pinyinSyllables(s string) int {
+	pinyinSyllableOnce.Do(func() {
+		args := pinyin.NewArgs()
+		args.Heteronym = true
+		pinyinSyllableSet = map[string]bool{}
+		for r := range pinyin.PinyinDict {
+			for _, v := range pinyin.SinglePinyin(rune(r), args) {
+				if isPinyin(v) && strings.ContainsAny(v, "aeiouv") {
+					pinyinSyllableSet[v] = true
+				}
+			}
+		}
+	})
+	// n[i] is max syllables of s[:i], -1 if can not split
+	n := make([]int, len(s)+1)
+	for i := 1; i <= len(s); i++ {
+		n[i] = -1
+		for j := i - 1; j >= 0 && i-j <= 6; j-- {
+			if n[j] >= 0 && n[j]+1 > n[i] && pinyinSyllableSet[s[j:i]] {
+				n[i] = n[j] + 1
+			}
+		}
+	}
+	if n[len(s)] < 0 {
+		return 0
+	}
+	return n[len(s)]
+}
+
+func isPinyin(s string) bool {
+	for _, c := range s {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// pinyinText names to index in pinyin field, empty if no han
+func (m *TorrentDocument) pinyinText() string {
+	s := m.TorrentFileName + "\n" + strings.TrimSuffix(m.MetaFileName, ".torrent")
+	if strings.IndexFunc(s, func(r rune) bool {
+		return unicode.Is(unicode.Han, r)
+	}) < 0 {
+		return ""
+	}
+	return s
+}
+
+func addPinyinField(doc *bluge.Document, m *TorrentDocument) {
+	if s := m.pinyinText(); s != "" {
+		doc.AddField(bluge.NewTextField(TorrentFieldPinyin, s).WithAnalyzer(pinyinAnalyzer).SearchTermPositions())
+	}
+}
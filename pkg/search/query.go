@@ -0,0 +1,422 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge"
+	"github.com/dustin/go-humanize"
+)
+
+// QueryError is a syntax error of query string
+type QueryError struct {
+	Pos int // byte offset in query
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("invalid query at %d: %s", e.Pos, e.Msg)
+}
+
+// query field prefix
+const (
+	QueryFieldName  = "name"  // torrent name
+	QueryFieldFile  = "file"  // meta file name
+	QueryFieldSize  = "size"  // total size, e.g. size:>4GB size:1GB..10GB
+	QueryFieldDate  = "date"  // creation date, e.g. date:2021 date:2021-06..2022
+	QueryFieldExt   = "ext"   // file extension, e.g. ext:mkv
+	QueryFieldFiles = "files" // file count, e.g. files:>100
+	QueryFieldSite  = "site"  // referer domain, e.g. site:example.com
+	QueryFieldPath  = "path"  // file path in torrent
+)
+
+var queryFields = map[string]bool{
+	QueryFieldName:  true,
+	QueryFieldFile:  true,
+	QueryFieldSize:  true,
+	QueryFieldDate:  true,
+	QueryFieldExt:   true,
+	QueryFieldFiles: true,
+	QueryFieldSite:  true,
+	QueryFieldPath:  true,
+}
+
+// ParseQuery compile query string to bluge query
+//
+// terms are matched on names and required by default, supports "quoted phrase", -exclusion,
+// OR between terms, (grouping) and field prefix, unknown prefix is a plain term.
+func ParseQuery(s string) (bluge.Query, error) {
+	toks, err := lexQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{toks: toks, src: s}
+	if len(toks) == 0 {
+		return bluge.NewMatchAllQuery(), nil
+	}
+	q, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return q, nil
+}
+
+type queryTokenKind int
+
+const (
+	queryTokenTerm queryTokenKind = iota
+	queryTokenPhrase
+	queryTokenOr
+	queryTokenOpen
+	queryTokenClose
+)
+
+type queryToken struct {
+	kind  queryTokenKind
+	pos   int
+	text  string // term or phrase without quote
+	field string
+	not   bool
+}
+
+func lexQuery(s string) (toks []*queryToken, err error) {
+	i := 0
+	for i < len(s) {
+		r := rune(s[i])
+		switch {
+		case spaceAt(s, i) > 0:
+			i += spaceAt(s, i)
+			continue
+		case r == '(' || r == '-' && i+1 < len(s) && s[i+1] == '(':
+			t := &queryToken{kind: queryTokenOpen, pos: i, text: "("}
+			if r == '-' {
+				t.not = true
+				i++
+			}
+			toks = append(toks, t)
+			i++
+			continue
+		case r == ')':
+			toks = append(toks, &queryToken{kind: queryTokenClose, pos: i, text: ")"})
+			i++
+			continue
+		}
+
+		t := &queryToken{kind: queryTokenTerm, pos: i}
+		if s[i] == '-' {
+			t.not = true
+			i++
+		}
+		// field prefix
+		if j := strings.IndexByte(s[i:], ':'); j > 0 {
+			if f := strings.ToLower(s[i : i+j]); queryFields[f] && !strings.ContainsAny(s[i:i+j], " \t\"()") {
+				t.field = f
+				i += j + 1
+			}
+		}
+		if i < len(s) && s[i] == '"' {
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, &QueryError{Pos: i, Msg: "unterminated quote"}
+			}
+			t.kind = queryTokenPhrase
+			t.text = s[i+1 : i+1+end]
+			i += end + 2
+		} else {
+			start := i
+			for i < len(s) && spaceAt(s, i) == 0 && s[i] != '(' && s[i] != ')' {
+				i++
+			}
+			t.text = s[start:i]
+		}
+		if t.text == "" {
+			switch {
+			case t.field != "":
+				return nil, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("missing value of %s", t.field)}
+			case t.kind == queryTokenTerm:
+				// dash between words, e.g. "title - episode"
+				continue
+			}
+		}
+		if t.kind == queryTokenTerm && t.field == "" && !t.not && t.text == "OR" {
+			t.kind = queryTokenOr
+		}
+		toks = append(toks, t)
+	}
+	return
+}
+
+type queryParser struct {
+	src  string
+	toks []*queryToken
+	i    int
+}
+
+func (p *queryParser) peek() *queryToken {
+	if p.i < len(p.toks) {
+		return p.toks[p.i]
+	}
+	return nil
+}
+
+func (p *queryParser) or() (bluge.Query, error) {
+	var qs []bluge.Query
+	for {
+		q, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+		t := p.peek()
+		if t == nil || t.kind != queryTokenOr {
+			break
+		}
+		p.i++
+	}
+	if len(qs) == 1 {
+		return qs[0], nil
+	}
+	return bluge.NewBooleanQuery().AddShould(qs...).SetMinShould(1), nil
+}
+
+func (p *queryParser) and() (bluge.Query, error) {
+	var must, not []bluge.Query
+	for {
+		t := p.peek()
+		if t == nil || t.kind == queryTokenClose {
+			break
+		}
+		if t.kind == queryTokenOr {
+			if len(must) == 0 && len(not) == 0 {
+				return nil, &QueryError{Pos: t.pos, Msg: "OR without left operand"}
+			}
+			break
+		}
+		p.i++
+		var q bluge.Query
+		var err error
+		if t.kind == queryTokenOpen {
+			if q, err = p.or(); err != nil {
+				return nil, err
+			}
+			if c := p.peek(); c == nil || c.kind != queryTokenClose {
+				return nil, &QueryError{Pos: t.pos, Msg: "unclosed parenthesis"}
+			}
+			p.i++
+		} else if q, err = compileQueryToken(t); err != nil {
+			return nil, err
+		}
+		if t.not {
+			not = append(not, q)
+		} else {
+			must = append(must, q)
+		}
+	}
+	if len(must) == 0 && len(not) == 0 {
+		pos := len(p.src)
+		if t := p.peek(); t != nil {
+			pos = t.pos
+		}
+		return nil, &QueryError{Pos: pos, Msg: "missing term"}
+	}
+	if len(must) == 1 && len(not) == 0 {
+		return must[0], nil
+	}
+	q := bluge.NewBooleanQuery()
+	if len(must) == 0 {
+		// only exclusions
+		q.AddMust(bluge.NewMatchAllQuery())
+	}
+	return q.AddMust(must...).AddMustNot(not...), nil
+}
+
+func compileQueryToken(t *queryToken) (bluge.Query, error) {
+	text := func(field string, boost float64) bluge.Query {
+		if t.kind == queryTokenPhrase {
+			return bluge.NewMatchPhraseQuery(t.text).SetField(field).SetAnalyzer(filenameQueryAnalyzer).SetBoost(boost)
+		}
+		// all tokens of a term, e.g. bigrams of 第一季
+		return bluge.NewMatchQuery(t.text).SetField(field).SetAnalyzer(filenameQueryAnalyzer).SetBoost(boost).SetOperator(bluge.MatchQueryOperatorAnd)
+	}
+	switch t.field {
+	case "":
+		// file paths and pinyin are noisy, rank lower than names
+		q := bluge.NewBooleanQuery().
+			AddShould(text(TorrentFieldTorrentFileName, 1), text(TorrentFieldMetaFileName, 1), text(TorrentFieldPath, 0.5)).
+			SetMinShould(1)
+		if py := pinyinQuery(t); py != nil {
+			q.AddShould(py)
+		}
+		return q, nil
+	case QueryFieldName:
+		return text(TorrentFieldTorrentFileName, 1), nil
+	case QueryFieldFile:
+		return text(TorrentFieldMetaFileName, 1), nil
+	case QueryFieldPath:
+		return text(TorrentFieldPath, 1), nil
+	case QueryFieldExt:
+		return bluge.NewTermQuery(normalizeExt(t.text)).SetField(docFieldExt), nil
+	case QueryFieldSite:
+		return bluge.NewTermQuery(NormalizeDomain(t.text)).SetField(docFieldDomain), nil
+	case QueryFieldSize:
+		return numericQuery(t, docFieldSize, func(s string) (float64, error) {
+			n, err := humanize.ParseBytes(s)
+			return float64(n), err
+		})
+	case QueryFieldFiles:
+		return numericQuery(t, docFieldFileCount, func(s string) (float64, error) {
+			n, err := strconv.ParseUint(s, 10, 64)
+			return float64(n), err
+		})
+	case QueryFieldDate:
+		return dateQuery(t)
+	}
+	return nil, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("unknown field %s", t.field)}
+}
+
+// pinyinQuery match latin letters of term in pinyin field, nil if term can not be pinyin
+func pinyinQuery(t *queryToken) bluge.Query {
+	if len(pinyinQueryAnalyzer.Analyze([]byte(t.text))) == 0 {
+		return nil
+	}
+	if t.kind == queryTokenPhrase {
+		return bluge.NewMatchPhraseQuery(t.text).SetField(TorrentFieldPinyin).SetAnalyzer(pinyinQueryAnalyzer).SetBoost(0.5)
+	}
+	return bluge.NewMatchQuery(t.text).SetField(TorrentFieldPinyin).SetAnalyzer(pinyinQueryAnalyzer).SetBoost(0.5).SetOperator(bluge.MatchQueryOperatorAnd)
+}
+
+// splitRange parse >v >=v <v <=v =v v a..b, open end of range is empty
+func splitRange(s string) (op string, a string, b string) {
+	for _, v := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, v) {
+			return v, s[len(v):], ""
+		}
+	}
+	if i := strings.Index(s, ".."); i >= 0 {
+		return "..", s[:i], s[i+2:]
+	}
+	return "", s, ""
+}
+
+func numericQuery(t *queryToken, field string, parse func(s string) (float64, error)) (bluge.Query, error) {
+	op, a, b := splitRange(t.text)
+	value := func(s string, def float64) (float64, error) {
+		if s == "" && op == ".." {
+			return def, nil
+		}
+		v, err := parse(s)
+		if err != nil {
+			return 0, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("invalid %s %q", t.field, s)}
+		}
+		return v, nil
+	}
+	min, err := value(a, bluge.MinNumeric)
+	if err != nil {
+		return nil, err
+	}
+	max := min
+	switch op {
+	case ">":
+		return bluge.NewNumericRangeInclusiveQuery(min, bluge.MaxNumeric, false, false).SetField(field), nil
+	case ">=":
+		return bluge.NewNumericRangeInclusiveQuery(min, bluge.MaxNumeric, true, false).SetField(field), nil
+	case "<":
+		return bluge.NewNumericRangeInclusiveQuery(bluge.MinNumeric, min, false, false).SetField(field), nil
+	case "<=":
+		return bluge.NewNumericRangeInclusiveQuery(bluge.MinNumeric, min, false, true).SetField(field), nil
+	case "..":
+		if max, err = value(b, bluge.MaxNumeric); err != nil {
+			return nil, err
+		}
+		if math.IsInf(min, -1) && math.IsInf(max, 1) {
+			return nil, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("empty %s range", t.field)}
+		}
+		if min > max {
+			return nil, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("invalid %s range %q", t.field, t.text)}
+		}
+	}
+	return bluge.NewNumericRangeInclusiveQuery(min, max, true, true).SetField(field), nil
+}
+
+// dateQuery match period of year, month or day
+func dateQuery(t *queryToken) (bluge.Query, error) {
+	op, a, b := splitRange(t.text)
+	period := func(s string) (start, end time.Time, err error) {
+		for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+			if start, err = time.ParseInLocation(layout, s, time.UTC); err == nil {
+				switch layout {
+				case "2006":
+					end = start.AddDate(1, 0, 0)
+				case "2006-01":
+					end = start.AddDate(0, 1, 0)
+				default:
+					end = start.AddDate(0, 0, 1)
+				}
+				return
+			}
+		}
+		return start, end, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("invalid date %q, expect YYYY, YYYY-MM or YYYY-MM-DD", s)}
+	}
+	var start, end time.Time
+	if a != "" || op != ".." {
+		s, e, err := period(a)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case ">":
+			start = e
+		case ">=":
+			start = s
+		case "<":
+			end = s
+		case "<=":
+			end = e
+		default:
+			start, end = s, e
+		}
+	}
+	if op == ".." {
+		end = time.Time{}
+		if b != "" {
+			_, e, err := period(b)
+			if err != nil {
+				return nil, err
+			}
+			end = e
+		}
+		if start.IsZero() && end.IsZero() {
+			return nil, &QueryError{Pos: t.pos, Msg: "empty date range"}
+		}
+		if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+			return nil, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("invalid date range %q", t.text)}
+		}
+	}
+	return bluge.NewDateRangeInclusiveQuery(start, end, true, false).SetField(docFieldCreatedAt), nil
+}
+
+// spaceAt return size of space rune at i, 0 if not space
+func spaceAt(s string, i int) int {
+	r, n := utf8.DecodeRuneInString(s[i:])
+	if unicode.IsSpace(r) {
+		return n
+	}
+	return 0
+}
+
+func normalizeExt(s string) string {
+	return strings.ToLower(strings.TrimPrefix(s, "."))
+}
+
+// NormalizeDomain lower case domain without www prefix
+func NormalizeDomain(s string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSuffix(s, ".")), "www.")
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	s, err := NewService(NewServiceOptions{DataDir: t.TempDir(), Write: true})
+	assert.NoError(t, err)
+	defer s.Close()
+
+	date := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02", s)
+		assert.NoError(t, err)
+		return v
+	}
+	assert.NoError(t, s.IndexTorrent(context.Background(), []*TorrentDocument{
+		{ID: "a", TorrentFileName: "Big Buck Bunny 1080p", MetaFileName: "bunny.torrent", Size: 5 << 30, CreatedAt: date("2021-03-01"), FileCount: 1, Exts: []string{".mkv"}},
+		{ID: "b", TorrentFileName: "Bunny Collection", MetaFileName: "collection.torrent", Size: 20 << 30, CreatedAt: date("2022-06-01"), FileCount: 200, Exts: []string{".mp4", ".srt"}},
+		{ID: "c", TorrentFileName: "Sintel 720p", MetaFileName: "sintel.torrent", Size: 512 << 20, CreatedAt: date("2020-01-01"), FileCount: 2, Exts: []string{".mkv"}},
+	}))
+
+	for _, test := range []struct {
+		q   string
+		ids []string
+	}{
+		{"bunny", []string{"a", "b"}},
+		{"bunny -collection", []string{"a"}},
+		{`"buck bunny"`, []string{"a"}},
+		{`"bunny buck"`, nil},
+		{"sintel OR collection", []string{"b", "c"}},
+		{"ext:mkv", []string{"a", "c"}},
+		{"ext:.SRT", []string{"b"}},
+		{"size:>4GB", []string{"a", "b"}},
+		{"size:1GB..10GB", []string{"a"}},
+		{"size:..2GiB", []string{"c"}},
+		{"date:2021..2022", []string{"a", "b"}},
+		{"date:2021", []string{"a"}},
+		{"date:<2021", []string{"c"}},
+		{"files:>100", []string{"b"}},
+		{"name:sintel", []string{"c"}},
+		{"file:collection", []string{"b"}},
+		{"-ext:mkv", []string{"b"}},
+		{"(sintel OR buck) ext:mkv -720p", []string{"a"}},
+		{"title - sintel", nil},
+		{"", []string{"a", "b", "c"}},
+	} {
+		r, err := s.SearchTorrent(context.Background(), &SearchRequest{QueryString: test.q})
+		if !assert.NoError(t, err, test.q) {
+			continue
+		}
+		var ids []string
+		for _, v := range r.Docs {
+			ids = append(ids, v.ID)
+		}
+		sort.Strings(ids)
+		assert.Equal(t, test.ids, ids, test.q)
+	}
+
+	for _, q := range []string{
+		`"open`,
+		"size:>big",
+		"size:..",
+		"size:10GB..1GB",
+		"date:2021-13",
+		"date:2022..2021",
+		"files:many",
+		"ext:",
+		"OR bunny",
+		"bunny OR",
+		"(bunny",
+		"bunny)",
+	} {
+		_, err := ParseQuery(q)
+		var qe *QueryError
+		assert.True(t, errors.As(err, &qe), q)
+	}
+}
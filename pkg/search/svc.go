@@ -4,9 +4,14 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/blugelabs/bluge/index"
+	"go.uber.org/multierr"
+
 	"github.com/rs/zerolog/log"
 
 	"github.com/blugelabs/bluge"
@@ -16,14 +21,18 @@ import (
 
 type NewServiceOptions struct {
 	DataDir string
+	Write   bool // open the writer, only one process can write, also enabled by BLUGE_WRITE=true
+	Pinyin  bool // index pinyin of han in names
 }
 
 func NewService(opts NewServiceOptions) (s *Service, err error) {
 	s = &Service{
 		Torrent: &CollectionIndex{},
+		pinyin:  opts.Pinyin,
+		meta:    filepath.Join(opts.DataDir, "torrent.json"),
 	}
 	config := bluge.DefaultConfig(filepath.Join(opts.DataDir, "torrent"))
-	if os.Getenv("BLUGE_WRITE") == "true" {
+	if opts.Write || os.Getenv("BLUGE_WRITE") == "true" {
 		s.Torrent.Writer, err = bluge.OpenWriter(config)
 		if err != nil {
 			return
@@ -32,25 +41,44 @@ func NewService(opts NewServiceOptions) (s *Service, err error) {
 		if err != nil {
 			return
 		}
+		err = s.checkVersion(true)
 	} else {
 		s.Torrent.Reader, err = bluge.OpenReader(config)
 		if err != nil {
 			return
 		}
+		err = s.checkVersion(false)
 	}
 	return
 }
 
 type Service struct {
-	Torrent *CollectionIndex
+	Torrent  *CollectionIndex
+	pinyin   bool
+	meta     string // path of indexMeta
+	outdated bool
+}
+
+// Writable has writer to update index
+func (s *Service) Writable() bool {
+	return s.Torrent.Writer != nil
+}
+
+func (s *Service) Close() error {
+	return s.Torrent.Close()
 }
 
 type SearchRequest struct {
-	QueryString string
+	QueryString string // parsed by ParseQuery
 	Query       bluge.Query
 	Limit       int
 	Offset      int
 	Orders      []string
+	Private     *bool    // filter private torrent, nil for any
+	Source      string   // filter by info source
+	HasWebSeed  *bool    // filter torrent has http web seed, nil for any
+	Filters     []string // selected facet values, e.g. ext:mkv
+	Facets      bool     // compute facets of all matched
 }
 
 type SearchResponse struct {
@@ -58,11 +86,13 @@ type SearchResponse struct {
 	Count    int
 	Duration time.Duration
 	MaxScore float64
+	Facets   []*Facet
 }
 type DocumentMatch struct {
 	ID        string
 	Score     float64
 	Locations search.FieldTermLocationMap
+	Files     []*FileMatch // matched file paths, at most MaxFileMatches
 }
 
 type TorrentDocument struct {
@@ -71,13 +101,31 @@ type TorrentDocument struct {
 	MetaFileName    string
 	TorrentFileName string
 	CreatedAt       time.Time
+	Private         bool
+	Source          string
+	HasWebSeed      bool
+	FileCount       int
+	IsDir           bool     // files are in dir of name, paths are loaded for dir torrent
+	Exts            []string // extensions of files
+	Domain          string   // referer domain
+	Paths           []string // file paths, at most MaxDocumentPaths are indexed
 }
 
 const (
 	TorrentFieldMetaFileName    = "file_name"
 	TorrentFieldTorrentFileName = "torrent_file_name"
+	TorrentFieldPath            = "path"
+	TorrentFieldPinyin          = "pinyin"
 	docFieldSize                = "size"
 	docFieldCreatedAt           = "created_at"
+	docFieldPrivate             = "private"
+	docFieldSource              = "source"
+	docFieldHasWebSeed          = "has_web_seed"
+	docFieldFileCount           = "file_count"
+	docFieldExt                 = "ext"
+	docFieldYear                = "year"
+	docFieldDomain              = "domain"
+	docValueTrue                = "T"
 )
 
 func (m *TorrentDocument) Document() *bluge.Document {
@@ -89,11 +137,37 @@ func (m *TorrentDocument) Document() *bluge.Document {
 	if m.TorrentFileName != "" {
 		doc.AddField(bluge.NewTextField(TorrentFieldTorrentFileName, m.TorrentFileName).WithAnalyzer(filenameAnalyzer).HighlightMatches())
 	}
+	if paths := documentPaths(m.Paths); paths != "" {
+		doc.AddField(bluge.NewTextField(TorrentFieldPath, paths).WithAnalyzer(filenameAnalyzer).StoreValue().HighlightMatches())
+	}
 	if m.Size != 0 {
 		doc.AddField(bluge.NewNumericField(docFieldSize, float64(m.Size)))
 	}
 	if !m.CreatedAt.IsZero() {
 		doc.AddField(bluge.NewDateTimeField(docFieldCreatedAt, m.CreatedAt))
+		if m.CreatedAt.Unix() > 0 {
+			doc.AddField(bluge.NewKeywordField(docFieldYear, strconv.Itoa(m.CreatedAt.UTC().Year())).Aggregatable())
+		}
+	}
+	if m.Private {
+		doc.AddField(bluge.NewKeywordField(docFieldPrivate, docValueTrue))
+	}
+	if m.Source != "" {
+		doc.AddField(bluge.NewKeywordField(docFieldSource, strings.ToLower(m.Source)))
+	}
+	if m.HasWebSeed {
+		doc.AddField(bluge.NewKeywordField(docFieldHasWebSeed, docValueTrue))
+	}
+	if m.FileCount != 0 {
+		doc.AddField(bluge.NewNumericField(docFieldFileCount, float64(m.FileCount)))
+	}
+	for _, v := range m.Exts {
+		if v = normalizeExt(v); v != "" {
+			doc.AddField(bluge.NewKeywordField(docFieldExt, v).Aggregatable())
+		}
+	}
+	if v := NormalizeDomain(m.Domain); v != "" {
+		doc.AddField(bluge.NewKeywordField(docFieldDomain, v).Aggregatable())
 	}
 	return doc
 }
@@ -103,17 +177,32 @@ type IsDocument interface {
 }
 
 func (s *Service) IndexTorrent(ctx context.Context, v []*TorrentDocument) (err error) {
+	return s.UpdateTorrent(ctx, v, nil)
+}
+
+// DeleteTorrent remove torrent documents, require writer
+func (s *Service) DeleteTorrent(ctx context.Context, ids []string) (err error) {
+	return s.UpdateTorrent(ctx, nil, ids)
+}
+
+// UpdateTorrent index and remove torrent documents in one batch, require writer
+func (s *Service) UpdateTorrent(ctx context.Context, docs []*TorrentDocument, deleted []string) (err error) {
 	batch := bluge.NewBatch()
-	for _, t := range v {
+	for _, t := range docs {
 		doc := t.Document()
+		if s.pinyin {
+			addPinyinField(doc, t)
+		}
 		id := doc.ID()
 		if len(id.Term()) == 0 {
 			return errors.New("invalid indexing: empty id")
 		}
 		batch.Update(id, doc)
 	}
-	err = s.Torrent.Writer.Batch(batch)
-	return
+	for _, id := range deleted {
+		batch.Delete(bluge.Identifier(id))
+	}
+	return s.Torrent.Batch(batch)
 }
 
 func (s *Service) SearchTorrent(ctx context.Context, req *SearchRequest) (resp *SearchResponse, err error) {
@@ -123,13 +212,24 @@ func (s *Service) SearchTorrent(ctx context.Context, req *SearchRequest) (resp *
 
 	query := req.Query
 	if query == nil {
-		query = bluge.NewBooleanQuery().
-			AddShould(bluge.NewMatchQuery(req.QueryString).SetField(TorrentFieldTorrentFileName)).
-			AddShould(bluge.NewMatchQuery(req.QueryString).SetField(TorrentFieldMetaFileName))
+		if query, err = ParseQuery(req.QueryString); err != nil {
+			return
+		}
+	}
+	if len(req.Filters) > 0 {
+		var fq bluge.Query
+		if fq, err = facetFilter(req.Filters); err != nil {
+			return
+		}
+		query = bluge.NewBooleanQuery().AddMust(query, fq)
 	}
+	query = filterQuery(query, req)
 
 	r := bluge.NewTopNSearch(req.Limit, query).SetFrom(req.Offset).WithStandardAggregations()
 	r = r.IncludeLocations()
+	if req.Facets {
+		addFacetAggregations(r)
+	}
 
 	if len(req.Orders) == 0 {
 		req.Orders = []string{"-created_at"}
@@ -137,6 +237,9 @@ func (s *Service) SearchTorrent(ctx context.Context, req *SearchRequest) (resp *
 	sorts := append([]string{"_score"}, req.Orders...)
 	r = r.SortBy(sorts)
 
+	// reader is not replaced during search
+	s.Torrent.mu.RLock()
+	defer s.Torrent.mu.RUnlock()
 	iterator, err := s.Torrent.Reader.Search(ctx, r)
 	if err != nil {
 		return
@@ -148,6 +251,9 @@ func (s *Service) SearchTorrent(ctx context.Context, req *SearchRequest) (resp *
 		Duration: agg.Duration(),
 		MaxScore: agg.Metric("max_score"),
 	}
+	if req.Facets {
+		resp.Facets = facets(agg)
+	}
 
 	var doc *search.DocumentMatch
 	for {
@@ -164,9 +270,12 @@ func (s *Service) SearchTorrent(ctx context.Context, req *SearchRequest) (resp *
 			Score:     doc.Score,
 		}
 		err = doc.VisitStoredFields(func(field string, value []byte) bool {
-			if field == "_id" {
+			switch field {
+			case "_id":
 				o.ID = string(value)
-			} else {
+			case TorrentFieldPath:
+				o.Files = matchFiles(string(value), doc.Locations[TorrentFieldPath])
+			default:
 				// fixme 暂时不考虑存储的字段
 				panic("found unexpected field: " + field)
 			}
@@ -185,10 +294,77 @@ func (s *Service) SearchTorrent(ctx context.Context, req *SearchRequest) (resp *
 	return
 }
 
+// filterQuery add filters of request to query
+func filterQuery(query bluge.Query, req *SearchRequest) bluge.Query {
+	if req.Private == nil && req.Source == "" && req.HasWebSeed == nil {
+		return query
+	}
+	q := bluge.NewBooleanQuery().AddMust(query)
+	flag := func(field string, v *bool) {
+		switch {
+		case v == nil:
+		case *v:
+			q.AddMust(bluge.NewTermQuery(docValueTrue).SetField(field))
+		default:
+			q.AddMustNot(bluge.NewTermQuery(docValueTrue).SetField(field))
+		}
+	}
+	flag(docFieldPrivate, req.Private)
+	flag(docFieldHasWebSeed, req.HasWebSeed)
+	if req.Source != "" {
+		q.AddMust(bluge.NewTermQuery(strings.ToLower(req.Source)).SetField(docFieldSource))
+	}
+	return q
+}
+
 type CollectionIndex struct {
 	Name   string
 	Reader *bluge.Reader
 	Writer *bluge.Writer
+	mu     sync.RWMutex
+}
+
+// Batch write to index and refresh reader, written documents are searchable after return
+func (c *CollectionIndex) Batch(batch *index.Batch) error {
+	if c.Writer == nil {
+		return errors.New("search index is read only")
+	}
+	if err := c.Writer.Batch(batch); err != nil {
+		return err
+	}
+	return c.Refresh()
+}
+
+// Refresh replace reader by near real time reader of writer
+func (c *CollectionIndex) Refresh() error {
+	if c.Writer == nil {
+		return nil
+	}
+	r, err := c.Writer.Reader()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	old := c.Reader
+	c.Reader = r
+	c.mu.Unlock()
+	if old != nil {
+		return old.Close()
+	}
+	return nil
+}
+
+func (c *CollectionIndex) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var errs []error
+	if c.Reader != nil {
+		errs = append(errs, c.Reader.Close())
+	}
+	if c.Writer != nil {
+		errs = append(errs, c.Writer.Close())
+	}
+	return multierr.Combine(errs...)
 }
 
 func MergeHTMLMark(s string) string {
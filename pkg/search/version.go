@@ -0,0 +1,72 @@
+package search
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// IndexVersion is increased when analysis of document changed, index of older version need reindex
+//
+//	1 - unicode words, no meta
+//	2 - han and kana bigrams, optional pinyin
+//	3 - prefixed pinyin initials
+const IndexVersion = 3
+
+// indexMeta describe how documents of the index are analyzed
+type indexMeta struct {
+	Version int  `json:"version"`
+	Pinyin  bool `json:"pinyin"`
+}
+
+// checkVersion mark index outdated if it's created by older version or options, empty index is up to date.
+// reader only check version, pinyin option only affect indexing.
+func (s *Service) checkVersion(write bool) error {
+	var m indexMeta
+	data, err := os.ReadFile(s.meta)
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(data, &m); err != nil {
+			return errors.Wrap(err, "parse search index meta")
+		}
+	case os.IsNotExist(err):
+		n, err := s.Torrent.Reader.Count()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			if write {
+				return s.MarkIndexed()
+			}
+			return nil
+		}
+		m.Version = 1
+	default:
+		return errors.Wrap(err, "read search index meta")
+	}
+	s.outdated = m.Version < IndexVersion || (write && m.Pinyin != s.pinyin)
+	if s.outdated && !write {
+		log.Warn().Int("version", m.Version).Int("current", IndexVersion).Msg("search index is outdated, search may miss results until search index")
+	}
+	return nil
+}
+
+// Outdated index need reindex all documents to be searchable by current analysis
+func (s *Service) Outdated() bool {
+	return s.outdated
+}
+
+// MarkIndexed record index is up to date, call after all documents are reindexed or queued to reindex
+func (s *Service) MarkIndexed() error {
+	data, err := json.Marshal(indexMeta{Version: IndexVersion, Pinyin: s.pinyin})
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(s.meta, data, 0o644); err != nil {
+		return errors.Wrap(err, "write search index meta")
+	}
+	s.outdated = false
+	return nil
+}
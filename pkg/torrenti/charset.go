@@ -0,0 +1,172 @@
+package torrenti
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// TextDecoder decode legacy encoded name and path of torrent to utf-8
+type TextDecoder struct {
+	Charset string // charset used to decode, empty if all text is utf-8
+	enc     encoding.Encoding
+}
+
+// charset candidates for detection, euc-kr is not detected since hangul overlaps with gb2312 hanzi
+var detectCharsets = []struct {
+	name  string
+	enc   encoding.Encoding
+	score func(b []byte) int
+}{
+	{"gbk", simplifiedchinese.GB18030, scoreGBK},
+	{"big5", traditionalchinese.Big5, scoreBig5},
+	{"shift_jis", japanese.ShiftJIS, scoreShiftJIS},
+}
+
+// NewTextDecoder select charset by declared encoding, detect from samples if declared is missing or not match
+func NewTextDecoder(declared string, samples ...string) *TextDecoder {
+	var invalid []string
+	for _, v := range samples {
+		if !utf8.ValidString(v) {
+			invalid = append(invalid, v)
+		}
+	}
+	d := &TextDecoder{}
+	if len(invalid) == 0 {
+		return d
+	}
+	if enc, err := htmlindex.Get(strings.TrimSpace(declared)); err == nil && decodeAll(enc, invalid) {
+		d.enc = enc
+		d.Charset, _ = htmlindex.Name(enc)
+		return d
+	}
+	best := 0
+	for _, c := range detectCharsets {
+		if !decodeAll(c.enc, invalid) {
+			continue
+		}
+		n := 0
+		for _, v := range invalid {
+			n += c.score([]byte(v))
+		}
+		if n > best {
+			best = n
+			d.enc = c.enc
+			d.Charset = c.name
+		}
+	}
+	return d
+}
+
+// String decode s if not valid utf-8, undecodable bytes are replaced by U+FFFD
+func (d *TextDecoder) String(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	if d.enc != nil {
+		if v, ok := decodeString(d.enc, s); ok {
+			return v
+		}
+	}
+	return strings.ToValidUTF8(s, "\uFFFD")
+}
+
+// Paths decode path components, utf-8 variant is preferred
+func (d *TextDecoder) Paths(paths []string, utf8Paths []string) []string {
+	if len(utf8Paths) == len(paths) && validStrings(utf8Paths) {
+		return utf8Paths
+	}
+	out := make([]string, len(paths))
+	for i, v := range paths {
+		out[i] = d.String(v)
+	}
+	return out
+}
+
+func validStrings(v []string) bool {
+	for _, s := range v {
+		if !utf8.ValidString(s) {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeString(enc encoding.Encoding, s string) (string, bool) {
+	v, err := enc.NewDecoder().String(s)
+	if err != nil || strings.ContainsRune(v, utf8.RuneError) {
+		return "", false
+	}
+	return v, true
+}
+
+func decodeAll(enc encoding.Encoding, samples []string) bool {
+	for _, v := range samples {
+		if _, ok := decodeString(enc, v); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// scoreGBK count gb2312 hanzi
+func scoreGBK(b []byte) (n int) {
+	for i := 0; i < len(b); {
+		switch {
+		case b[i] < 0x80:
+			i++
+			continue
+		case i+1 < len(b) && b[i+1] >= 0x30 && b[i+1] <= 0x39:
+			// gb18030 four bytes
+			i += 4
+			continue
+		case i+1 < len(b) && b[i] >= 0xb0 && b[i] <= 0xf7 && b[i+1] >= 0xa1:
+			n++
+		}
+		i += 2
+	}
+	return
+}
+
+// scoreBig5 count frequently used hanzi
+func scoreBig5(b []byte) (n int) {
+	for i := 0; i < len(b); {
+		if b[i] < 0x80 {
+			i++
+			continue
+		}
+		if i+1 < len(b) && b[i] >= 0xa4 && b[i] <= 0xc6 {
+			n++
+		}
+		i += 2
+	}
+	return
+}
+
+// scoreShiftJIS count kana and jis level 1 kanji
+func scoreShiftJIS(b []byte) (n int) {
+	for i := 0; i < len(b); {
+		c := b[i]
+		if c < 0x80 || c >= 0xa1 && c <= 0xdf {
+			// half width katakana is rarely used
+			i++
+			continue
+		}
+		if i+1 < len(b) {
+			t := b[i+1]
+			switch {
+			case c == 0x82 && t >= 0x9f && t <= 0xf1, // hiragana
+				c == 0x83 && t >= 0x40 && t <= 0x96, // katakana
+				c >= 0x88 && c <= 0x98:
+				n++
+			}
+		}
+		i += 2
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+func TestTextDecoder(t *testing.T) {
+	enc := func(e encoding.Encoding, s string) string {
+		v, err := e.NewEncoder().String(s)
+		assert.NoError(t, err)
+		return v
+	}
+	for _, test := range []struct {
+		declared string
+		text     string
+		enc      encoding.Encoding
+		charset  string
+	}{
+		{"", "中文字幕 第01集", simplifiedchinese.GBK, "gbk"},
+		{"", "繁體中文字幕", traditionalchinese.Big5, "big5"},
+		{"", "ひらがなとカタカナの日本語", japanese.ShiftJIS, "shift_jis"},
+		{"GBK", "中文", simplifiedchinese.GBK, "gbk"},
+		{"big5", "中文", traditionalchinese.Big5, "big5"},
+		{"UTF-8", "中文字幕", simplifiedchinese.GBK, "gbk"},
+		{"", "plain", nil, ""},
+	} {
+		s := test.text
+		if test.enc != nil {
+			s = enc(test.enc, s)
+		}
+		d := NewTextDecoder(test.declared, s)
+		assert.Equal(t, test.charset, d.Charset, test.text)
+		assert.Equal(t, test.text, d.String(s), test.text)
+	}
+	assert.Equal(t, "a\uFFFDb", NewTextDecoder("").String("a\xffb"))
+}
+
+func TestIndexTorrentCharset(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	gbk := func(s string) string {
+		v, err := simplifiedchinese.GBK.NewEncoder().String(s)
+		assert.NoError(t, err)
+		return v
+	}
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"comment": gbk("说明"),
+		"info": map[string]interface{}{
+			"name":         gbk("中文目录"),
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 20)),
+			"files": []interface{}{
+				map[string]interface{}{"length": 1, "path": []string{gbk("第一集.mkv")}},
+				map[string]interface{}{"length": 2, "path": []string{gbk("字幕"), "x.srt"}, "path.utf-8": []string{"字幕", "y.srt"}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	tor := &Torrent{Data: data, FileInfo: &util.File{Path: "test.torrent", Length: int64(len(data))}}
+	_, err = svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+
+	var tt models.Torrent
+	assert.NoError(t, svc.DB.Find(&tt).Error)
+	assert.Equal(t, "中文目录", tt.Name)
+	assert.Equal(t, "gbk", tt.Charset)
+	assert.Equal(t, []byte(tor.Meta.InfoBytes), tt.InfoBytes)
+
+	var paths []string
+	assert.NoError(t, svc.DB.Model(models.TorrentFile{}).Order("id").Pluck("path", &paths).Error)
+	assert.Equal(t, []string{"第一集.mkv", "字幕/y.srt"}, paths)
+
+	var mf models.MetaFile
+	assert.NoError(t, svc.DB.Find(&mf).Error)
+	assert.Equal(t, "说明", mf.Comment)
+	raw, _, err := LoadTorrentData(ctx, svc.DB, &mf)
+	assert.NoError(t, err)
+	assert.Equal(t, data, raw)
+}
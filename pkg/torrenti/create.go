@@ -0,0 +1,341 @@
+package torrenti
+
+import (
+	"context"
+	"crypto/sha1"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xgfone/bt/bencode"
+)
+
+// version of created torrent
+const (
+	CreateVersionV1     = "v1"
+	CreateVersionV2     = "v2"
+	CreateVersionHybrid = "hybrid"
+)
+
+type CreateTorrentOptions struct {
+	Path         string
+	Name         string // default to base name of path
+	Version      string // v1, v2 or hybrid, default to v1
+	PieceLength  int64  // power of two and at least 16KiB, auto select if zero
+	Trackers     [][]string
+	WebSeeds     []string
+	Private      bool
+	Source       string
+	Comment      string
+	CreatedBy    string
+	CreationDate time.Time // omitted if zero
+}
+
+type createFile struct {
+	path   string   // local path
+	paths  []string // path in torrent
+	length int64
+	attr   string
+}
+
+// CreateTorrent build torrent from local file or directory, hidden files and non regular files are skipped
+//
+// files of hybrid torrent are aligned to piece by BEP 47 padding files.
+func CreateTorrent(ctx context.Context, o CreateTorrentOptions) (data []byte, err error) {
+	switch o.Version {
+	case "":
+		o.Version = CreateVersionV1
+	case CreateVersionV1, CreateVersionV2, CreateVersionHybrid:
+	default:
+		return nil, errors.Errorf("invalid version: %q", o.Version)
+	}
+	fi, err := os.Stat(o.Path)
+	if err != nil {
+		return
+	}
+	if o.Name == "" {
+		o.Name = filepath.Base(filepath.Clean(o.Path))
+	}
+	files, err := createFiles(o.Path, fi)
+	if err != nil {
+		return
+	}
+	if !fi.IsDir() {
+		files[0].paths = []string{o.Name}
+	}
+	var total int64
+	for _, f := range files {
+		total += f.length
+	}
+	if total == 0 {
+		return nil, errors.New("no content")
+	}
+	if o.PieceLength == 0 {
+		o.PieceLength = autoPieceLength(total)
+	}
+	if o.PieceLength < 16*1024 || o.PieceLength&(o.PieceLength-1) != 0 {
+		return nil, errors.Errorf("invalid piece length: %v", o.PieceLength)
+	}
+
+	v1 := o.Version != CreateVersionV2
+	v2 := o.Version != CreateVersionV1
+	hybrid := v1 && v2
+	info := map[string]interface{}{
+		"name":         o.Name,
+		"piece length": o.PieceLength,
+	}
+	if o.Private {
+		info["private"] = 1
+	}
+	if o.Source != "" {
+		info["source"] = o.Source
+	}
+
+	var ph *pieceHasher
+	if v1 {
+		ph = &pieceHasher{length: o.PieceLength, h: sha1.New()}
+	}
+	tree := map[string]interface{}{}
+	layers := map[string]interface{}{}
+	var list []interface{}
+	for i, f := range files {
+		var root []byte
+		var layer [][]byte
+		if err = func() error {
+			r, err := os.Open(f.path)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			lr := &io.LimitedReader{R: r, N: f.length}
+			rd := io.Reader(lr)
+			if ph != nil {
+				rd = io.TeeReader(rd, ph)
+			}
+			if v2 {
+				root, layer, err = merkleFile(ctx, rd, o.PieceLength)
+			} else {
+				_, err = io.Copy(io.Discard, rd)
+			}
+			if err == nil && lr.N != 0 {
+				err = errors.New("file changed")
+			}
+			return err
+		}(); err != nil {
+			return nil, errors.Wrapf(err, "hash %s", f.path)
+		}
+
+		if v1 {
+			entry := map[string]interface{}{"length": f.length, "path": f.paths}
+			if f.attr != "" {
+				entry["attr"] = f.attr
+			}
+			list = append(list, entry)
+			// align next file
+			if pad := (o.PieceLength - f.length%o.PieceLength) % o.PieceLength; hybrid && pad > 0 && i < len(files)-1 {
+				ph.pad(pad)
+				list = append(list, map[string]interface{}{
+					"attr":   "p",
+					"length": pad,
+					"path":   []string{".pad", strconv.FormatInt(pad, 10)},
+				})
+			}
+		}
+		if v2 {
+			leaf := map[string]interface{}{"length": f.length}
+			if f.length > 0 {
+				leaf["pieces root"] = string(root)
+			}
+			if f.attr != "" {
+				leaf["attr"] = f.attr
+			}
+			node := tree
+			for _, p := range f.paths[:len(f.paths)-1] {
+				next, _ := node[p].(map[string]interface{})
+				if next == nil {
+					next = map[string]interface{}{}
+					node[p] = next
+				}
+				node = next
+			}
+			node[f.paths[len(f.paths)-1]] = map[string]interface{}{"": leaf}
+			if len(layer) > 0 {
+				layers[string(root)] = string(concatBytes(layer))
+			}
+		}
+	}
+
+	if v1 {
+		info["pieces"] = string(ph.sum())
+		if fi.IsDir() {
+			info["files"] = list
+		} else {
+			info["length"] = files[0].length
+		}
+	}
+	if v2 {
+		info["meta version"] = 2
+		info["file tree"] = tree
+	}
+
+	d := map[string]interface{}{
+		"info": info,
+	}
+	if v2 && len(layers) > 0 {
+		d["piece layers"] = layers
+	}
+	trackers := make([][]string, 0, len(o.Trackers))
+	for _, tier := range o.Trackers {
+		if len(tier) > 0 {
+			trackers = append(trackers, tier)
+		}
+	}
+	if len(trackers) > 0 {
+		d["announce"] = trackers[0][0]
+		if len(trackers) > 1 || len(trackers[0]) > 1 {
+			d["announce-list"] = trackers
+		}
+	}
+	if len(o.WebSeeds) > 0 {
+		d["url-list"] = o.WebSeeds
+	}
+	if o.Comment != "" {
+		d["comment"] = o.Comment
+	}
+	if o.CreatedBy != "" {
+		d["created by"] = o.CreatedBy
+	}
+	if !o.CreationDate.IsZero() {
+		d["creation date"] = o.CreationDate.Unix()
+	}
+	return bencode.EncodeBytes(d)
+}
+
+// ParseTrackerTiers parse tiers of trackers separated by comma
+func ParseTrackerTiers(tiers []string) (out [][]string) {
+	for _, v := range tiers {
+		var tier []string
+		for _, u := range strings.Split(v, ",") {
+			if u = strings.TrimSpace(u); u != "" {
+				tier = append(tier, u)
+			}
+		}
+		if len(tier) > 0 {
+			out = append(out, tier)
+		}
+	}
+	return
+}
+
+// createFiles list files in torrent order
+func createFiles(root string, fi fs.FileInfo) (files []*createFile, err error) {
+	if !fi.IsDir() {
+		if !fi.Mode().IsRegular() {
+			return nil, errors.Errorf("not a regular file: %s", root)
+		}
+		return []*createFile{{path: root, paths: []string{fi.Name()}, length: fi.Size(), attr: fileModeAttr(fi.Mode())}}, nil
+	}
+	err = filepath.WalkDir(root, func(fn string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if fn == root {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, fn)
+		if err != nil {
+			return err
+		}
+		files = append(files, &createFile{
+			path:   fn,
+			paths:  strings.Split(filepath.ToSlash(rel), "/"),
+			length: info.Size(),
+			attr:   fileModeAttr(info.Mode()),
+		})
+		return nil
+	})
+	return
+}
+
+func fileModeAttr(m fs.FileMode) string {
+	if m&0o111 != 0 {
+		return "x"
+	}
+	return ""
+}
+
+// autoPieceLength select piece length for about 1000 to 2000 pieces, between 16KiB and 16MiB
+func autoPieceLength(total int64) int64 {
+	n := int64(16 * 1024)
+	for n < 16<<20 && total/n > 2000 {
+		n <<= 1
+	}
+	return n
+}
+
+func concatBytes(v [][]byte) []byte {
+	var out []byte
+	for _, b := range v {
+		out = append(out, b...)
+	}
+	return out
+}
+
+// pieceHasher hash v1 pieces of written content
+type pieceHasher struct {
+	length int64
+	h      hash.Hash
+	n      int64 // written of current piece
+	pieces []byte
+}
+
+func (p *pieceHasher) Write(b []byte) (int, error) {
+	written := len(b)
+	for len(b) > 0 {
+		c := p.length - p.n
+		if int64(len(b)) < c {
+			c = int64(len(b))
+		}
+		p.h.Write(b[:c])
+		p.n += c
+		b = b[c:]
+		if p.n == p.length {
+			p.pieces = p.h.Sum(p.pieces)
+			p.h.Reset()
+			p.n = 0
+		}
+	}
+	return written, nil
+}
+
+func (p *pieceHasher) pad(n int64) {
+	_, _ = io.CopyN(p, zeroReader{}, n)
+}
+
+func (p *pieceHasher) sum() []byte {
+	if p.n > 0 {
+		p.pieces = p.h.Sum(p.pieces)
+		p.h.Reset()
+		p.n = 0
+	}
+	return p.pieces
+}
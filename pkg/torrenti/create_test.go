@@ -0,0 +1,64 @@
+package torrenti
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+func TestCreateTorrent(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+	dir := filepath.Join(t.TempDir(), "content")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.bin"), bytes.Repeat([]byte("a"), 40000), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.bin"), bytes.Repeat([]byte("b"), 1000), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "empty"), nil, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("x"), 0o644))
+
+	for _, version := range []string{CreateVersionV1, CreateVersionV2, CreateVersionHybrid} {
+		data, err := CreateTorrent(ctx, CreateTorrentOptions{
+			Path:        dir,
+			Version:     version,
+			PieceLength: 16384,
+			Trackers:    [][]string{{"http://a.example.com/announce"}, {"udp://b.example.com:80"}},
+			WebSeeds:    []string{"https://example.com/"},
+			Private:     true,
+			Source:      "test",
+		})
+		assert.NoError(t, err, version)
+
+		tor := &Torrent{Data: data, FileInfo: &util.File{Path: "content.torrent", Length: int64(len(data))}}
+		assert.NoError(t, tor.Load())
+		assert.Equal(t, version != CreateVersionV2, tor.Info.IsV1(), version)
+		assert.Equal(t, version != CreateVersionV1, tor.Info.IsV2(), version)
+		assert.True(t, tor.Info.IsPrivate())
+		assert.Equal(t, int64(41000), tor.Info.ContentLength())
+		assert.False(t, Lint(tor).HasError(), "%v %v", version, Lint(tor))
+
+		files, err := tor.Info.Files()
+		assert.NoError(t, err)
+		var paths []string
+		for _, f := range files {
+			if !f.IsPadding() {
+				paths = append(paths, f.Path())
+			}
+		}
+		assert.Equal(t, []string{"a.bin", "empty", "sub/b.bin"}, paths, version)
+
+		r, err := VerifyData(ctx, tor.Info, tor.PieceLayers, filepath.Dir(dir))
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, r.Complete(), version)
+		for _, f := range r.Files {
+			assert.True(t, f.RootMatch == nil || *f.RootMatch, version)
+		}
+
+		_, err = svc.IndexTorrent(ctx, tor)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,142 @@
+package torrenti
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeleteTorrentRequest struct {
+	Hashes        []string // v1 or v2 info hash, tombstoned even if not indexed
+	ContentHashes []string // content hash of meta file, delete the torrent of meta file
+	Reason        string
+}
+
+type DeleteTorrentResult struct {
+	Hashes       []string // deleted torrent hashes
+	MetaCount    int64
+	TorrentCount int64
+}
+
+// DeleteTorrent remove meta files, torrent and files, tombstone prevent the torrent be indexed again
+func (idx *Service) DeleteTorrent(ctx context.Context, req *DeleteTorrentRequest) (r *DeleteTorrentResult, err error) {
+	db := idx.DB.WithContext(ctx)
+	var keys []string
+	for _, v := range req.Hashes {
+		h, err := magnet.ParseHash(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid hash %q", v)
+		}
+		keys = append(keys, h.String())
+	}
+
+	// hash to tombstone
+	tombstones := map[string]*models.Tombstone{}
+	for _, v := range keys {
+		tombstones[v] = &models.Tombstone{TorrentHash: v, Reason: req.Reason}
+	}
+	var found []*models.Torrent
+	for _, chunk := range lo.Chunk(keys, IndexFileBatchSize) {
+		var out []*models.Torrent
+		if err = db.Select("hash", "hash_v2", "name").Where("hash in (?) or hash_v2 in (?)", chunk, chunk).Find(&out).Error; err != nil {
+			return nil, errors.Wrap(err, "find torrent")
+		}
+		found = append(found, out...)
+	}
+	for _, chunk := range lo.Chunk(req.ContentHashes, IndexFileBatchSize) {
+		var out []string
+		if err = db.Model(models.MetaFile{}).Where("content_hash in (?)", chunk).Pluck("torrent_hash", &out).Error; err != nil {
+			return nil, errors.Wrap(err, "find meta file")
+		}
+		for _, v := range out {
+			if tombstones[v] == nil {
+				tombstones[v] = &models.Tombstone{TorrentHash: v, Reason: req.Reason}
+			}
+		}
+		var more []*models.Torrent
+		if err = db.Select("hash", "hash_v2", "name").Where("hash in (?)", out).Find(&more).Error; err != nil {
+			return nil, errors.Wrap(err, "find torrent")
+		}
+		found = append(found, more...)
+	}
+	for _, v := range found {
+		// v2 hash resolved to the stored hash
+		delete(tombstones, v.HashV2)
+		tombstones[v.Hash] = &models.Tombstone{TorrentHash: v.Hash, HashV2: v.HashV2, Name: v.Name, Reason: req.Reason}
+	}
+
+	r = &DeleteTorrentResult{Hashes: lo.Keys(tombstones)}
+	sort.Strings(r.Hashes)
+	if len(r.Hashes) == 0 {
+		return
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, chunk := range lo.Chunk(r.Hashes, IndexFileBatchSize) {
+			var raws []string
+			if err := tx.Model(&models.MetaFile{}).Where("torrent_hash in (?) and raw_hash != ''", chunk).Distinct().Pluck("raw_hash", &raws).Error; err != nil {
+				return errors.Wrap(err, "find meta blob")
+			}
+			ret := tx.Where("torrent_hash in (?)", chunk).Delete(&models.MetaFile{})
+			if ret.Error != nil {
+				return errors.Wrap(ret.Error, "delete meta file")
+			}
+			r.MetaCount += ret.RowsAffected
+			if err := deleteRawTx(tx, raws); err != nil {
+				return err
+			}
+			ret = tx.Where("hash in (?)", chunk).Delete(&models.Torrent{})
+			if ret.Error != nil {
+				return errors.Wrap(ret.Error, "delete torrent")
+			}
+			r.TorrentCount += ret.RowsAffected
+			for _, m := range []interface{}{
+				&models.TorrentFile{},
+				&models.TorrentTracker{},
+				&models.TorrentWebSeed{},
+				&models.TorrentNode{},
+				&models.LintFinding{},
+				&models.TorrentDuplicate{},
+			} {
+				if err := tx.Where("torrent_hash in (?)", chunk).Delete(m).Error; err != nil {
+					return errors.Wrap(err, "delete torrent relation")
+				}
+			}
+		}
+		ret := tx.Clauses(clause.OnConflict{
+			Columns:   models.Tombstone{}.ConflictColumns(),
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "updated_at"}),
+		}).CreateInBatches(lo.Values(tombstones), IndexFileBatchSize)
+		if err := errors.Wrap(ret.Error, "save tombstone"); err != nil {
+			return err
+		}
+		return enqueueSearchTx(tx, r.Hashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	idx.notifySearch()
+	log.Info().Int("torrents", len(r.Hashes)).Int64("meta", r.MetaCount).Str("reason", req.Reason).Msg("delete torrent")
+	return
+}
+
+// IsTombstoned check any of the hashes is deleted
+func (idx *Service) IsTombstoned(ctx context.Context, hashes ...string) (bool, error) {
+	var n int64
+	err := idx.DB.WithContext(ctx).Model(models.Tombstone{}).
+		Where("torrent_hash in (?) or hash_v2 in (?)", hashes, hashes).
+		Count(&n).Error
+	return n > 0, errors.Wrap(err, "find tombstone")
+}
+
+// ListTombstones return all tombstoned torrent hashes
+func (idx *Service) ListTombstones(ctx context.Context) (out []string, err error) {
+	err = idx.DB.WithContext(ctx).Model(models.Tombstone{}).Order("id").Pluck("torrent_hash", &out).Error
+	return
+}
@@ -0,0 +1,49 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+func TestDeleteTorrent(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	a := newTestTorrent(t, "a", 2)
+	b := newTestTorrent(t, "b", 2)
+	for _, v := range []*Torrent{a, b} {
+		_, err := svc.IndexTorrent(ctx, v)
+		assert.NoError(t, err)
+	}
+
+	r, err := svc.DeleteTorrent(ctx, &DeleteTorrentRequest{
+		ContentHashes: []string{util.ContentHashBytes(a.Data)},
+		Hashes:        []string{"0123456789abcdef0123456789abcdef01234567"},
+		Reason:        "takedown",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, r.Hashes, 2)
+	assert.Equal(t, int64(1), r.MetaCount)
+	assert.Equal(t, int64(1), r.TorrentCount)
+
+	var n int64
+	assert.NoError(t, svc.DB.Model(models.TorrentFile{}).Where(models.TorrentFile{TorrentHash: a.Hash.String()}).Count(&n).Error)
+	assert.Zero(t, n)
+	assert.NoError(t, svc.DB.Model(models.TorrentFile{}).Count(&n).Error)
+	assert.Equal(t, int64(2), n)
+
+	// not indexed again
+	stat, err := svc.IndexTorrent(ctx, newTestTorrent(t, "a", 2))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stat.TombstoneCount)
+	assert.Zero(t, stat.MetaCount)
+
+	stat, err = svc.Stat(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), stat.TombstoneCount)
+	assert.Equal(t, int64(1), stat.TorrentCount)
+}
@@ -0,0 +1,220 @@
+package torrenti
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"gorm.io/gorm"
+)
+
+// match kind of duplicate torrent
+const (
+	DuplicateMatchPaths = "paths" // same relative paths and sizes
+	DuplicateMatchSizes = "sizes" // same multiset of file sizes above threshold
+)
+
+type ClusterDuplicatesOptions struct {
+	MinSize   int64 // min total size to match, also min file size for sizes match, default 10MiB
+	BatchSize int
+}
+
+type ClusterDuplicatesStat struct {
+	Torrents int
+	Groups   int
+	Members  int
+}
+
+// ClusterDuplicates group torrents with same content and replace stored groups
+func (idx *Service) ClusterDuplicates(ctx context.Context, o ClusterDuplicatesOptions) (stat *ClusterDuplicatesStat, err error) {
+	if o.MinSize <= 0 {
+		o.MinSize = 10 << 20
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 1000
+	}
+	stat = &ClusterDuplicatesStat{}
+	db := idx.DB.WithContext(ctx)
+
+	c := &duplicateCluster{
+		parent: map[string]string{},
+		order:  map[string]int{},
+		first:  map[string]string{},
+		match:  map[string]string{},
+	}
+	var lastID uint
+	for {
+		var torrents []*models.Torrent
+		err = db.Select("id", "hash").
+			Where("id > ? and total_file_size >= ?", lastID, o.MinSize).
+			Order("id").Limit(o.BatchSize).
+			Find(&torrents).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "find torrents")
+		}
+		if len(torrents) == 0 {
+			break
+		}
+		lastID = torrents[len(torrents)-1].ID
+
+		var files []*models.TorrentFile
+		err = db.Select("torrent_hash", "path", "size").
+			Where("torrent_hash in (?)", lo.Map(torrents, func(t *models.Torrent, i int) string {
+				return t.Hash
+			})).
+			Find(&files).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "find torrent files")
+		}
+		byHash := lo.GroupBy(files, func(t *models.TorrentFile) string {
+			return t.TorrentHash
+		})
+		for _, t := range torrents {
+			stat.Torrents++
+			c.add(t.Hash, byHash[t.Hash], o.MinSize)
+		}
+	}
+
+	var rows []*models.TorrentDuplicate
+	groups := map[string][]string{}
+	for h := range c.parent {
+		r := c.find(h)
+		groups[r] = append(groups[r], h)
+	}
+	for g, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		stat.Groups++
+		for _, h := range members {
+			rows = append(rows, &models.TorrentDuplicate{TorrentHash: h, GroupHash: g, Match: c.match[h]})
+		}
+	}
+	stat.Members = len(rows)
+	sort.Slice(rows, func(i, j int) bool {
+		return c.order[rows[i].TorrentHash] < c.order[rows[j].TorrentHash]
+	})
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.TorrentDuplicate{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, o.BatchSize).Error
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "save duplicates")
+	}
+	log.Info().Int("torrents", stat.Torrents).Int("groups", stat.Groups).Int("members", stat.Members).Msg("cluster duplicates")
+	return
+}
+
+// LoadDuplicateGroups return group hash of torrents in duplicate group
+func LoadDuplicateGroups(ctx context.Context, db *gorm.DB, hashes []string) (map[string]string, error) {
+	out := map[string]string{}
+	if len(hashes) == 0 {
+		return out, nil
+	}
+	var rows []*models.TorrentDuplicate
+	if err := db.WithContext(ctx).Select("torrent_hash", "group_hash").Where("torrent_hash in (?)", hashes).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, v := range rows {
+		out[v.TorrentHash] = v.GroupHash
+	}
+	return out, nil
+}
+
+// duplicateCluster is an union find of torrent hash, root is the first added torrent
+type duplicateCluster struct {
+	parent map[string]string
+	order  map[string]int
+	first  map[string]string // fingerprint to first torrent
+	match  map[string]string
+}
+
+func (c *duplicateCluster) add(hash string, files []*models.TorrentFile, minSize int64) {
+	c.parent[hash] = hash
+	c.order[hash] = len(c.order)
+	if len(files) == 0 {
+		return
+	}
+	if fp := pathsFingerprint(files); fp != "" {
+		c.link(hash, DuplicateMatchPaths+":"+fp, DuplicateMatchPaths)
+	}
+	if fp := sizesFingerprint(files, minSize); fp != "" {
+		c.link(hash, DuplicateMatchSizes+":"+fp, DuplicateMatchSizes)
+	}
+}
+
+func (c *duplicateCluster) link(hash string, fp string, match string) {
+	other, ok := c.first[fp]
+	if !ok {
+		c.first[fp] = hash
+		return
+	}
+	for _, h := range []string{hash, other} {
+		if c.match[h] == "" || match == DuplicateMatchPaths {
+			c.match[h] = match
+		}
+	}
+	a, b := c.find(hash), c.find(other)
+	if a == b {
+		return
+	}
+	if c.order[a] < c.order[b] {
+		c.parent[b] = a
+	} else {
+		c.parent[a] = b
+	}
+}
+
+func (c *duplicateCluster) find(h string) string {
+	for c.parent[h] != h {
+		c.parent[h] = c.parent[c.parent[h]]
+		h = c.parent[h]
+	}
+	return h
+}
+
+func pathsFingerprint(files []*models.TorrentFile) string {
+	lines := lo.Map(files, func(t *models.TorrentFile, i int) string {
+		return t.Path + "\x00" + strconv.FormatInt(t.Size, 10)
+	})
+	sort.Strings(lines)
+	h := sha256.New()
+	for _, v := range lines {
+		h.Write([]byte(v))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sizesFingerprint(files []*models.TorrentFile, minSize int64) string {
+	var sizes []int64
+	for _, v := range files {
+		if v.Size >= minSize {
+			sizes = append(sizes, v.Size)
+		}
+	}
+	if len(sizes) == 0 {
+		return ""
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		return sizes[i] < sizes[j]
+	})
+	h := sha256.New()
+	for _, v := range sizes {
+		h.Write([]byte(strconv.FormatInt(v, 10)))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
+)
+
+func TestClusterDuplicates(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	index := func(name string, pieceLength int, files map[string]int) string {
+		var list []interface{}
+		for k, v := range files {
+			list = append(list, map[string]interface{}{"length": v, "path": []string{k}})
+		}
+		data, err := bencode.EncodeBytes(map[string]interface{}{
+			"info": map[string]interface{}{
+				"name":         name,
+				"piece length": pieceLength,
+				"pieces":       string(make([]byte, 20)),
+				"files":        list,
+			},
+		})
+		assert.NoError(t, err)
+		tor := &Torrent{Data: data, FileInfo: &util.File{Path: name + ".torrent", Length: int64(len(data))}}
+		_, err = svc.IndexTorrent(ctx, tor)
+		assert.NoError(t, err)
+		return tor.Hash.String()
+	}
+	a := index("a", 16384, map[string]int{"a.mkv": 20 << 20, "a.nfo": 100})
+	b := index("b", 32768, map[string]int{"a.mkv": 20 << 20, "a.nfo": 100})
+	c := index("c", 16384, map[string]int{"c.mkv": 20 << 20, "c.nfo": 200})
+	d := index("d", 16384, map[string]int{"d.mkv": 30 << 20})
+	index("e", 16384, map[string]int{"a.nfo": 100})
+
+	stat, err := svc.ClusterDuplicates(ctx, ClusterDuplicatesOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, stat.Torrents)
+	assert.Equal(t, 1, stat.Groups)
+	assert.Equal(t, 3, stat.Members)
+
+	groups, err := LoadDuplicateGroups(ctx, svc.DB, []string{a, b, c, d})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{a: a, b: a, c: a}, groups)
+
+	var dup models.TorrentDuplicate
+	assert.NoError(t, svc.DB.Where(models.TorrentDuplicate{TorrentHash: c}).Find(&dup).Error)
+	assert.Equal(t, DuplicateMatchSizes, dup.Match)
+
+	// groups are replaced
+	_, err = svc.ClusterDuplicates(ctx, ClusterDuplicatesOptions{MinSize: 25 << 20})
+	assert.NoError(t, err)
+	groups, err = LoadDuplicateGroups(ctx, svc.DB, []string{a, b, c, d})
+	assert.NoError(t, err)
+	assert.Empty(t, groups)
+}
@@ -0,0 +1,281 @@
+package torrenti
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/xgfone/bt/bencode"
+	"gorm.io/gorm"
+)
+
+type ExportOptions struct {
+	Hashes        []string  // torrent hash or meta file content hash, nil for all, empty for none
+	Since         time.Time // meta file indexed time
+	Until         time.Time
+	RefererDomain string // match domain and sub domain of referer
+	Limit         int
+}
+
+// SearchedHashes narrow hashes to searched torrent hashes, all searched if hashes is nil
+func SearchedHashes(hashes []string, searched []string) []string {
+	if hashes == nil {
+		return searched
+	}
+	m := make(map[string]bool, len(hashes))
+	for _, v := range hashes {
+		if h, err := magnet.ParseHash(v); err == nil {
+			v = h.String()
+		}
+		m[v] = true
+	}
+	out := make([]string, 0, len(searched))
+	for _, v := range searched {
+		if m[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+type ExportFile struct {
+	Name    string // safe unique file name
+	Data    []byte
+	ModTime time.Time
+	Meta    *models.MetaFile
+	Exact   bool // false if rebuilt data not match the content hash
+}
+
+// ExportTorrent rebuild .torrent of matched meta files
+func (idx *Service) ExportTorrent(ctx context.Context, o ExportOptions, cb func(f *ExportFile) error) (err error) {
+	if o.Hashes != nil && len(o.Hashes) == 0 {
+		return
+	}
+	db := idx.DB.WithContext(ctx).Model(models.MetaFile{})
+	if o.Hashes != nil {
+		hashes := make([]string, 0, len(o.Hashes))
+		for _, v := range o.Hashes {
+			hashes = append(hashes, v)
+			if h, err := magnet.ParseHash(v); err == nil {
+				hashes = append(hashes, h.String())
+			}
+		}
+		db = db.Where("torrent_hash in (?) or content_hash in (?)", hashes, hashes)
+	}
+	if !o.Since.IsZero() {
+		db = db.Where("created_at >= ?", o.Since)
+	}
+	if !o.Until.IsZero() {
+		db = db.Where("created_at < ?", o.Until)
+	}
+	if d := strings.ToLower(strings.TrimSpace(o.RefererDomain)); d != "" {
+		db = db.Where("lower(referer) like ? or lower(referer) like ? or lower(referer) like ? or lower(referer) like ?",
+			"%://"+d, "%://"+d+"/%", "%."+d, "%."+d+"/%")
+	}
+
+	names := map[string]bool{}
+	n := 0
+	var out []*models.MetaFile
+	ret := db.Preload("Torrent").FindInBatches(&out, 100, func(tx *gorm.DB, batch int) error {
+		for _, v := range out {
+			if o.Limit > 0 && n >= o.Limit {
+				return errStopExport
+			}
+			if v.Torrent == nil {
+				continue
+			}
+			data, exact, err := LoadTorrentData(ctx, idx.DB, v)
+			if err != nil {
+				return errors.Wrapf(err, "load torrent %s", v.ContentHash)
+			}
+			if !exact {
+				log.Warn().Str("content_hash", v.ContentHash).Msg("export rebuilt torrent, not byte exact")
+			}
+			name := SafeFilename(v.Filename)
+			if name == "" {
+				name = strings.TrimPrefix(v.TorrentHash, "urn:btih:")
+			}
+			if names[strings.ToLower(name)] {
+				name += "-" + v.ContentHash[:8]
+			}
+			names[strings.ToLower(name)] = true
+
+			f := &ExportFile{
+				Name:    name + ".torrent",
+				Data:    data,
+				ModTime: v.CreatedAt,
+				Meta:    v,
+				Exact:   exact,
+			}
+			if v.CreationDate > 0 {
+				f.ModTime = time.Unix(v.CreationDate, 0)
+			}
+			if err = cb(f); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	if errors.Is(ret.Error, errStopExport) {
+		return nil
+	}
+	return ret.Error
+}
+
+var errStopExport = errors.New("stop export")
+
+// BuildTorrentData rebuild .torrent from meta raw and torrent info, binary values may not survive the json raw
+func BuildTorrentData(mf *models.MetaFile) (data []byte, err error) {
+	if mf.Torrent == nil {
+		return nil, errors.New("torrent not loaded")
+	}
+	d := map[string]interface{}{}
+	if len(mf.Raw) > 0 {
+		if err = json.Unmarshal(mf.Raw, &d); err != nil {
+			return nil, errors.Wrap(err, "decode meta raw")
+		}
+	}
+	d = jsonToBencode(d).(map[string]interface{})
+	d["info"] = bencode.RawMessage(mf.Torrent.InfoBytes)
+	if len(mf.Torrent.PieceLayers) > 0 {
+		d["piece layers"] = bencode.RawMessage(mf.Torrent.PieceLayers)
+	}
+	return bencode.EncodeBytes(d)
+}
+
+// jsonToBencode convert json number to int
+func jsonToBencode(in interface{}) interface{} {
+	switch vv := in.(type) {
+	case float64:
+		return int64(vv)
+	case []interface{}:
+		for i, v := range vv {
+			vv[i] = jsonToBencode(v)
+		}
+	case map[string]interface{}:
+		for k, v := range vv {
+			vv[k] = jsonToBencode(v)
+		}
+	}
+	return in
+}
+
+// SafeFilename make a portable file name without .torrent suffix
+func SafeFilename(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	if strings.HasSuffix(strings.ToLower(s), ".torrent") {
+		s = s[:len(s)-len(".torrent")]
+	}
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(`/\:*?"<>|`, r), unicode.IsControl(r):
+			return '_'
+		}
+		return r
+	}, s)
+	s = strings.Trim(s, " .")
+	// keep room for suffix, most file system limit name to 255 bytes
+	const max = 200
+	if len(s) > max {
+		i := max
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		s = strings.TrimRight(s[:i], " .")
+	}
+	return s
+}
+
+// ExportWriter write exported files to dir or archive
+type ExportWriter interface {
+	WriteFile(f *ExportFile) error
+	Close() error
+}
+
+func NewExportWriter(format string, w io.Writer, dir string) (ExportWriter, error) {
+	switch format {
+	case "dir":
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		return dirExportWriter(dir), nil
+	case "tar":
+		return &tarExportWriter{w: tar.NewWriter(w)}, nil
+	case "zip":
+		return &zipExportWriter{w: zip.NewWriter(w)}, nil
+	default:
+		return nil, errors.Errorf("unsupported export format: %q", format)
+	}
+}
+
+type dirExportWriter string
+
+func (d dirExportWriter) WriteFile(f *ExportFile) error {
+	fn := filepath.Join(string(d), f.Name)
+	if err := os.WriteFile(fn, f.Data, 0o644); err != nil {
+		return err
+	}
+	return os.Chtimes(fn, f.ModTime, f.ModTime)
+}
+
+func (d dirExportWriter) Close() error {
+	return nil
+}
+
+type tarExportWriter struct {
+	w *tar.Writer
+}
+
+func (t *tarExportWriter) WriteFile(f *ExportFile) error {
+	err := t.w.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     f.Name,
+		Size:     int64(len(f.Data)),
+		Mode:     0o644,
+		ModTime:  f.ModTime,
+		Format:   tar.FormatPAX,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = t.w.Write(f.Data)
+	return err
+}
+
+func (t *tarExportWriter) Close() error {
+	return t.w.Close()
+}
+
+type zipExportWriter struct {
+	w *zip.Writer
+}
+
+func (z *zipExportWriter) WriteFile(f *ExportFile) error {
+	w, err := z.w.CreateHeader(&zip.FileHeader{
+		Name:     f.Name,
+		Method:   zip.Deflate,
+		Modified: f.ModTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(f.Data)
+	return err
+}
+
+func (z *zipExportWriter) Close() error {
+	return z.w.Close()
+}
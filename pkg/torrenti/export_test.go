@@ -0,0 +1,57 @@
+package torrenti
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportTorrent(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	a := newTestTorrent(t, "a", 2)
+	_, err := svc.IndexTorrent(ctx, a)
+	assert.NoError(t, err)
+	b := newTestTorrent(t, "b", 3)
+	_, err = svc.IndexTorrent(ctx, b)
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	ew, err := NewExportWriter("zip", buf, "")
+	assert.NoError(t, err)
+	assert.NoError(t, svc.ExportTorrent(ctx, ExportOptions{Hashes: []string{a.Hash.HexHash()}}, ew.WriteFile))
+	assert.NoError(t, ew.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, zr.File, 1)
+	assert.Equal(t, "a.torrent", zr.File[0].Name)
+	r, err := zr.File[0].Open()
+	assert.NoError(t, err)
+	data, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, a.Data, data)
+
+	n := 0
+	assert.NoError(t, svc.ExportTorrent(ctx, ExportOptions{Hashes: []string{}}, func(f *ExportFile) error {
+		n++
+		return nil
+	}))
+	assert.Zero(t, n)
+
+	assert.Equal(t, "a_b_c", SafeFilename("a/b\\c.torrent"))
+	assert.Equal(t, "x", SafeFilename(" ..x.. "))
+}
+
+func TestSearchedHashes(t *testing.T) {
+	a := "urn:btih:631a31dd0a46257d5078c0dee4e66e26f73e42ac"
+	b := "urn:btih:d8dd32ac93357c368556af3ac1d95c9d76bd0dff"
+	assert.Equal(t, []string{a, b}, SearchedHashes(nil, []string{a, b}))
+	assert.Equal(t, []string{b}, SearchedHashes([]string{"d8dd32ac93357c368556af3ac1d95c9d76bd0dff"}, []string{a, b}))
+	assert.Empty(t, SearchedHashes([]string{}, []string{a, b}))
+}
@@ -0,0 +1,84 @@
+package torrenti
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"gorm.io/gorm"
+)
+
+// FileRootMatch is a file of torrent with same v2 pieces root
+type FileRootMatch struct {
+	File    *models.TorrentFile
+	Torrent *models.Torrent
+}
+
+// PiecesRoot compute BEP 52 pieces root of content, nil for empty content
+func PiecesRoot(ctx context.Context, r io.Reader) ([]byte, error) {
+	// root is independent of piece length
+	root, _, err := merkleFile(ctx, r, math.MaxInt64)
+	return root, err
+}
+
+// FilePiecesRoot compute pieces root of local file
+func FilePiecesRoot(ctx context.Context, fn string) ([]byte, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return PiecesRoot(ctx, f)
+}
+
+// NormalizePiecesRoot validate hex encoded pieces root
+func NormalizePiecesRoot(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if b, err := hex.DecodeString(s); err != nil || len(b) != 32 {
+		return "", errors.Errorf("invalid pieces root: %q", s)
+	}
+	return s, nil
+}
+
+// FindFileRoot find files of torrents by hex encoded pieces root, scopes apply to file query
+func FindFileRoot(ctx context.Context, db *gorm.DB, root string, offset int, limit int, scopes ...func(db *gorm.DB) *gorm.DB) (out []*FileRootMatch, err error) {
+	if root, err = NormalizePiecesRoot(root); err != nil {
+		return
+	}
+	db = db.WithContext(ctx)
+	var files []*models.TorrentFile
+	query := db.Where(models.TorrentFile{PiecesRoot: root}).Scopes(scopes...).Order("id").Offset(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err = query.Find(&files).Error; err != nil {
+		return nil, errors.Wrap(err, "find torrent file")
+	}
+	if len(files) == 0 {
+		return
+	}
+	var torrents []*models.Torrent
+	err = db.Omit("info_bytes", "piece_layers").
+		Where("hash in (?)", lo.Map(files, func(t *models.TorrentFile, i int) string {
+			return t.TorrentHash
+		})).
+		Find(&torrents).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find torrent")
+	}
+	byHash := lo.KeyBy(torrents, func(t *models.Torrent) string {
+		return t.Hash
+	})
+	for _, v := range files {
+		if t := byHash[v.TorrentHash]; t != nil {
+			out = append(out, &FileRootMatch{File: v, Torrent: t})
+		}
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package torrenti
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
+)
+
+func TestFindFileRoot(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	content := []byte("hello")
+	root, err := PiecesRoot(ctx, bytes.NewReader(content))
+	assert.NoError(t, err)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, sum[:], root)
+
+	for _, name := range []string{"a", "b"} {
+		data, err := bencode.EncodeBytes(map[string]interface{}{
+			"info": map[string]interface{}{
+				"name":         name,
+				"piece length": 16384,
+				"meta version": 2,
+				"file tree": map[string]interface{}{
+					"hello.txt": map[string]interface{}{"": map[string]interface{}{"length": len(content), "pieces root": string(root)}},
+					"other.txt": map[string]interface{}{"": map[string]interface{}{"length": 3, "pieces root": string(make([]byte, 32))}},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		_, err = svc.IndexTorrent(ctx, &Torrent{Data: data, FileInfo: &util.File{Path: name + ".torrent", Length: int64(len(data))}})
+		assert.NoError(t, err)
+	}
+
+	out, err := FindFileRoot(ctx, svc.DB, hex.EncodeToString(root), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, out, 2)
+	assert.Equal(t, "hello.txt", out[0].File.Path)
+	assert.Equal(t, "a", out[0].Torrent.Name)
+
+	_, err = FindFileRoot(ctx, svc.DB, "bad", 0, 10)
+	assert.Error(t, err)
+}
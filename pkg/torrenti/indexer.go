@@ -3,10 +3,12 @@ package torrenti
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"unicode/utf8"
 
 	"go.uber.org/multierr"
 
@@ -14,9 +16,11 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
 
 	"github.com/pkg/errors"
 	"github.com/xgfone/bt/bencode"
+	"github.com/xgfone/bt/metainfo"
 	"gorm.io/gorm/clause"
 
 	"github.com/wenerme/torrenti/pkg/torrenti/models"
@@ -24,22 +28,36 @@ import (
 )
 
 type Service struct {
-	DB *gorm.DB
+	DB   *gorm.DB
+	Lint LintMode
+
+	searchNotify chan struct{}
 }
 
 type NewServiceOptions struct {
-	DB *gorm.DB
+	DB   *gorm.DB
+	Lint LintMode // default lint mode when indexing
 }
 
 func NewIndexer(o NewServiceOptions) (*Service, error) {
 	if o.DB == nil {
 		return nil, errors.New("db is nil")
 	}
-	idx := &Service{DB: o.DB}
+	idx := &Service{DB: o.DB, Lint: o.Lint, searchNotify: make(chan struct{}, 1)}
 	if err := idx.DB.Migrator().AutoMigrate(
 		models.MetaFile{},
 		models.Torrent{},
 		models.TorrentFile{},
+		models.Tracker{},
+		models.TorrentTracker{},
+		models.LintFinding{},
+		models.TorrentWebSeed{},
+		models.TorrentNode{},
+		models.TorrentDuplicate{},
+		models.Tombstone{},
+		models.Checkpoint{},
+		models.MetaBlob{},
+		models.SearchOutbox{},
 	); err != nil {
 		return nil, err
 	}
@@ -53,11 +71,16 @@ type IndexTorrentStat struct {
 	TorrentCount         int64
 	TorrentFileCount     int64
 	TorrentFileTotalSize int64
+	TrackerCount         int64
+	QuarantinedCount     int64
+	TombstoneCount       int64
 }
 
 type IndexTorrentOptions struct {
-	Stat  *IndexTorrentStat
-	Force bool //  if already exists, force to re-index
+	Stat        *IndexTorrentStat
+	Force       bool     // if already exists, force to re-index and update derived columns
+	Lint        LintMode // override service lint mode
+	ContentHash string   // override content hash of data, used when data is rebuilt from stored meta file
 }
 type IndexTorrentRequest struct {
 	File *util.File
@@ -73,6 +96,9 @@ func (idx *Service) Stat(ctx context.Context) (stat *IndexTorrentStat, err error
 		db.Model(models.Torrent{}).Count(&stat.TorrentCount).Error,
 		db.Model(models.TorrentFile{}).Count(&stat.TorrentFileCount).Error,
 		db.Model(models.Torrent{}).Select("coalesce(sum(total_file_size),0)").Scan(&stat.TorrentFileTotalSize).Error,
+		db.Model(models.Tracker{}).Count(&stat.TrackerCount).Error,
+		db.Model(models.MetaFile{}).Where("quarantined").Count(&stat.QuarantinedCount).Error,
+		db.Model(models.Tombstone{}).Count(&stat.TombstoneCount).Error,
 	)
 	return
 }
@@ -87,7 +113,7 @@ func (idx *Service) IndexTorrent(ctx context.Context, t *Torrent, opts ...func(o
 	stat = o.Stat
 
 	if t.Meta == nil {
-		err = t.Load()
+		err = t.LoadContext(ctx)
 		if err != nil {
 			return
 		}
@@ -95,6 +121,18 @@ func (idx *Service) IndexTorrent(ctx context.Context, t *Torrent, opts ...func(o
 
 	mi := t.Meta
 
+	hashes := []string{t.Hash.String()}
+	if !t.HashV2.IsZero() {
+		hashes = append(hashes, t.HashV2.String())
+	}
+	if deleted, err := idx.IsTombstoned(ctx, hashes...); err != nil || deleted {
+		if deleted {
+			log.Debug().Str("hash", t.Hash.String()).Msg("skip tombstoned torrent")
+			stat.TombstoneCount++
+		}
+		return stat, err
+	}
+
 	mf := models.MetaFile{
 		Filename:     t.FileInfo.Name(),
 		ContentHash:  util.ContentHashBytes(t.Data),
@@ -109,11 +147,11 @@ func (idx *Service) IndexTorrent(ctx context.Context, t *Torrent, opts ...func(o
 		Raw:          nil,
 		RawBytes:     nil,
 	}
+	// data rebuilt from legacy meta file is not original, stored raw is kept
+	exact := o.ContentHash == "" || o.ContentHash == mf.ContentHash
 	m := map[string]interface{}{}
-	err = bencode.NewDecoder(bytes.NewReader(t.Data)).Decode(&m)
-	err = errors.Wrap(err, "decode data")
-	if err != nil {
-		return
+	if err = bencode.NewDecoder(bytes.NewReader(t.Data)).Decode(&m); err != nil {
+		return stat, errors.Wrap(err, "decode data")
 	}
 
 	delete(m, "info")
@@ -126,91 +164,371 @@ func (idx *Service) IndexTorrent(ctx context.Context, t *Torrent, opts ...func(o
 		mf.CreationDate = reflect.ValueOf(m["save date"]).Int()
 	}
 
-	mf.Raw, err = json.Marshal(m)
-	err = errors.Wrap(err, "json.Marshal data")
-	if err != nil {
-		return
+	if mf.Raw, err = json.Marshal(m); err != nil {
+		return stat, errors.Wrap(err, "json.Marshal data")
 	}
-	{
-		ret := idx.DB.Clauses(clause.OnConflict{
-			Columns:   mf.ConflictColumns(),
-			DoNothing: true,
-		}).Create(&mf)
-		if err = errors.Wrap(ret.Error, "save meta"); err != nil {
-			return
+	if o.ContentHash != "" {
+		mf.ContentHash = o.ContentHash
+	}
+
+	mode := o.Lint
+	if mode == "" {
+		mode = idx.Lint
+	}
+	findings := Lint(t)
+	lfs := make([]models.LintFinding, 0, len(findings))
+	for _, v := range findings {
+		if v.Severity == SeverityInfo {
+			// not worth to store for every torrent
+			continue
 		}
-		stat.MetaCount += ret.RowsAffected
-		log.Debug().
-			Str("file", t.FileInfo.Name()).Str("size", humanize.Bytes(uint64(t.FileInfo.Size()))).
-			Int64("affected", ret.RowsAffected).
-			Msg("index meta")
-		if ret.RowsAffected == 0 && !o.Force {
+		lfs = append(lfs, models.LintFinding{
+			ContentHash: mf.ContentHash,
+			TorrentHash: mf.TorrentHash,
+			Severity:    string(v.Severity),
+			Code:        v.Code,
+			Path:        v.Path,
+			Message:     v.Message,
+		})
+	}
+	if findings.HasError() {
+		log.Warn().Str("file", mf.Filename).Str("hash", mf.TorrentHash).Str("mode", string(mode)).Msg(findings[0].String())
+		switch mode {
+		case LintModeReject:
+			return stat, &InvalidTorrentError{Findings: findings}
+		case LintModeQuarantine:
+			mf.Quarantined = true
+			var n int64
+			err = idx.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if exact {
+					if err := saveRawTx(tx, &mf, t.Data, nil); err != nil {
+						return err
+					}
+				}
+				ret := tx.Clauses(metaConflict(&mf, o.Force)).Create(&mf)
+				if err := errors.Wrap(ret.Error, "save meta"); err != nil {
+					return err
+				}
+				n = ret.RowsAffected
+				return indexLintTx(tx, mf.ContentHash, lfs, o.Force)
+			})
+			if err == nil {
+				stat.QuarantinedCount += n
+			}
 			return
 		}
 	}
 
-	info, err := mi.Info()
+	info := t.Info
+	if info == nil {
+		var v Info
+		if v, err = ParseInfo(mi.InfoBytes); err != nil {
+			return
+		}
+		info = &v
+	}
+	files, err := info.Files()
 	if err != nil {
 		return
 	}
-	files := info.AllFiles()
+	files = lo.Filter(files, func(f InfoFile, i int) bool {
+		return !f.IsPadding()
+	})
+
+	// name and paths are decoded, original bytes are kept in info bytes
+	samples := []string{info.Name}
+	for _, f := range files {
+		samples = append(samples, f.Paths...)
+	}
+	dec := NewTextDecoder(mi.Encoding, samples...)
+	name := info.NameUTF8
+	if name == "" || !utf8.ValidString(name) {
+		name = dec.String(info.Name)
+	}
+	if v, ok := m["comment.utf-8"].(string); ok && v != "" && utf8.ValidString(v) {
+		mf.Comment = v
+	} else {
+		mf.Comment = dec.String(mf.Comment)
+	}
+	mf.CreatedBy = dec.String(mf.CreatedBy)
+
 	tt := models.Torrent{
 		Hash:          t.Hash.String(),
-		Name:          info.Name,
-		TotalFileSize: info.TotalLength(),
+		MetaVersion:   info.MetaVersion,
+		Name:          name,
+		Charset:       dec.Charset,
+		TotalFileSize: info.ContentLength(),
 		FileCount:     len(files),
 		PieceCount:    info.CountPieces(),
 		IsDir:         info.IsDir(),
 		InfoBytes:     mi.InfoBytes,
+		PieceLayers:   t.PieceLayers,
+		Private:       info.IsPrivate(),
+		Source:        info.Source,
 	}
-	{
-		ret := idx.DB.Clauses(clause.OnConflict{
-			Columns:   tt.ConflictColumns(),
-			DoNothing: true,
-		}).Create(&tt)
-		if err = errors.Wrap(ret.Error, "save torrent"); err != nil {
-			return
-		}
-		stat.TorrentCount += ret.RowsAffected
-		stat.TorrentFileTotalSize += tt.TotalFileSize
-		log.Debug().
-			Str("name", info.Name).Int("files", tt.FileCount).Str("size", humanize.Bytes(uint64(tt.TotalFileSize))).
-			Int64("affected", ret.RowsAffected).
-			Msg("index torrent")
-		if ret.RowsAffected == 0 && !o.Force {
-			return
-		}
+	if !t.HashV2.IsZero() {
+		tt.HashV2 = t.HashV2.String()
 	}
-
+	tfs := make([]models.TorrentFile, 0, len(files))
 	for _, f := range files {
 		tf := models.TorrentFile{
 			TorrentHash: tt.Hash,
-			Path:        strings.Join(f.Paths, "/"),
+			Path:        strings.Join(dec.Paths(f.Paths, f.PathsUTF8), "/"),
 			Size:        f.Length,
+			PiecesRoot:  hex.EncodeToString(f.PiecesRoot),
+			Attr:        f.Attr,
+			SymlinkPath: strings.Join(dec.Paths(f.SymlinkPath, nil), "/"),
+			SHA1:        hex.EncodeToString(f.SHA1),
 		}
 
-		if info.IsDir() {
-			tf.Path = info.Name
+		if !info.IsDir() {
+			tf.Path = name
 		}
 
 		tf.Filename = filepath.Base(tf.Path)
 		tf.Ext = filepath.Ext(tf.Path)
+		tfs = append(tfs, tf)
+	}
+
+	trackers := Trackers(mi)
+	webSeeds := WebSeeds(t)
+	nodes := Nodes(mi)
+
+	// only count when committed
+	st := &IndexTorrentStat{}
+	err = idx.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := indexLintTx(tx, mf.ContentHash, lfs, o.Force); err != nil {
+			return err
+		}
+		if exact {
+			if err := saveRawTx(tx, &mf, t.Data, mi.InfoBytes); err != nil {
+				return err
+			}
+		}
+		if err := indexTorrentTx(tx, st, &mf, &tt, tfs, o.Force); err != nil {
+			return err
+		}
+		// meta file quarantined before is indexed now, e.g. lint mode changed
+		err := tx.Model(&models.MetaFile{}).Where("content_hash = ? and quarantined", mf.ContentHash).Update("quarantined", false).Error
+		if err = errors.Wrap(err, "clear meta quarantined"); err != nil {
+			return err
+		}
+		// children of torrent
+		if err := indexTrackerTx(tx, st, tt.Hash, trackers, o.Force); err != nil {
+			return err
+		}
+		if err := indexSeedTx(tx, tt.Hash, webSeeds, nodes, o.Force); err != nil {
+			return err
+		}
+		// unchanged torrent is already searchable
+		if st.MetaCount+st.TorrentCount+st.TorrentFileCount == 0 && !o.Force {
+			return nil
+		}
+		return enqueueSearchTx(tx, []string{tt.Hash})
+	})
+	if err != nil {
+		return
+	}
+	idx.notifySearch()
+	stat.MetaCount += st.MetaCount
+	stat.TorrentCount += st.TorrentCount
+	stat.TorrentFileCount += st.TorrentFileCount
+	stat.TorrentFileTotalSize += st.TorrentFileTotalSize
+	stat.TrackerCount += st.TrackerCount
+	return
+}
+
+func indexLintTx(tx *gorm.DB, contentHash string, lfs []models.LintFinding, force bool) error {
+	if force {
+		if err := tx.Where(models.LintFinding{ContentHash: contentHash}).Delete(&models.LintFinding{}).Error; err != nil {
+			return errors.Wrap(err, "delete lint finding")
+		}
+	}
+	if len(lfs) == 0 {
+		return nil
+	}
+	ret := tx.Clauses(clause.OnConflict{
+		Columns:   models.LintFinding{}.ConflictColumns(),
+		DoNothing: true,
+	}).CreateInBatches(lfs, IndexFileBatchSize)
+	return errors.Wrap(ret.Error, "save lint finding")
+}
 
-		ret := idx.DB.Clauses(clause.OnConflict{
-			Columns:   tf.ConflictColumns(),
+// indexTrackerTx write trackers and link to torrent, trackers from new meta file are merged, links are rebuilt when force
+func indexTrackerTx(tx *gorm.DB, stat *IndexTorrentStat, hash string, trackers []TrackerURL, force bool) error {
+	if force {
+		if err := tx.Where(models.TorrentTracker{TorrentHash: hash}).Delete(&models.TorrentTracker{}).Error; err != nil {
+			return errors.Wrap(err, "delete torrent tracker")
+		}
+	}
+	if len(trackers) == 0 {
+		return nil
+	}
+	trs := make([]models.Tracker, 0, len(trackers))
+	tts := make([]models.TorrentTracker, 0, len(trackers))
+	for _, v := range trackers {
+		trs = append(trs, models.Tracker{URL: v.URL, Protocol: v.Protocol})
+		tts = append(tts, models.TorrentTracker{TorrentHash: hash, TrackerURL: v.URL, Tier: v.Tier})
+	}
+	ret := tx.Clauses(clause.OnConflict{
+		Columns:   models.Tracker{}.ConflictColumns(),
+		DoNothing: true,
+	}).CreateInBatches(trs, IndexFileBatchSize)
+	if err := errors.Wrap(ret.Error, "save tracker"); err != nil {
+		return err
+	}
+	stat.TrackerCount += ret.RowsAffected
+
+	ret = tx.Clauses(clause.OnConflict{
+		Columns:   models.TorrentTracker{}.ConflictColumns(),
+		DoNothing: true,
+	}).CreateInBatches(tts, IndexFileBatchSize)
+	return errors.Wrap(ret.Error, "save torrent tracker")
+}
+
+// indexSeedTx write web seeds and dht nodes, torrent is marked when has web seed, rebuilt when force
+func indexSeedTx(tx *gorm.DB, hash string, webSeeds []WebSeedURL, nodes []metainfo.HostAddress, force bool) error {
+	if force {
+		if err := tx.Where(models.TorrentWebSeed{TorrentHash: hash}).Delete(&models.TorrentWebSeed{}).Error; err != nil {
+			return errors.Wrap(err, "delete torrent web seed")
+		}
+		if err := tx.Where(models.TorrentNode{TorrentHash: hash}).Delete(&models.TorrentNode{}).Error; err != nil {
+			return errors.Wrap(err, "delete torrent node")
+		}
+		err := tx.Model(&models.Torrent{}).Where("hash = ?", hash).Update("has_web_seed", len(webSeeds) > 0).Error
+		if err = errors.Wrap(err, "update torrent web seed"); err != nil {
+			return err
+		}
+	}
+	if len(webSeeds) > 0 {
+		wss := make([]models.TorrentWebSeed, 0, len(webSeeds))
+		for _, v := range webSeeds {
+			wss = append(wss, models.TorrentWebSeed{TorrentHash: hash, URL: v.URL, Type: v.Type})
+		}
+		ret := tx.Clauses(clause.OnConflict{
+			Columns:   models.TorrentWebSeed{}.ConflictColumns(),
+			DoNothing: true,
+		}).CreateInBatches(wss, IndexFileBatchSize)
+		if err := errors.Wrap(ret.Error, "save torrent web seed"); err != nil {
+			return err
+		}
+		err := tx.Model(&models.Torrent{}).Where("hash = ? and not has_web_seed", hash).Update("has_web_seed", true).Error
+		if err = errors.Wrap(err, "update torrent web seed"); err != nil {
+			return err
+		}
+	}
+	if len(nodes) > 0 {
+		tns := make([]models.TorrentNode, 0, len(nodes))
+		for _, v := range nodes {
+			tns = append(tns, models.TorrentNode{TorrentHash: hash, Host: v.Host, Port: int(v.Port)})
+		}
+		ret := tx.Clauses(clause.OnConflict{
+			Columns:   models.TorrentNode{}.ConflictColumns(),
+			DoNothing: true,
+		}).CreateInBatches(tns, IndexFileBatchSize)
+		return errors.Wrap(ret.Error, "save torrent node")
+	}
+	return nil
+}
+
+// IndexFileBatchSize number of torrent files inserted in one statement
+const IndexFileBatchSize = 500
+
+// indexTorrentTx write meta, torrent and files, torrent with partial files is completed
+func indexTorrentTx(tx *gorm.DB, stat *IndexTorrentStat, mf *models.MetaFile, tt *models.Torrent, tfs []models.TorrentFile, force bool) error {
+	{
+		ret := tx.Clauses(metaConflict(mf, force)).Create(mf)
+		if err := errors.Wrap(ret.Error, "save meta"); err != nil {
+			return err
+		}
+		stat.MetaCount += ret.RowsAffected
+		log.Debug().
+			Str("file", mf.Filename).Str("size", humanize.Bytes(uint64(mf.Size))).
+			Int64("affected", ret.RowsAffected).
+			Msg("index meta")
+	}
+
+	{
+		onConflict := clause.OnConflict{
+			Columns:   tt.ConflictColumns(),
 			DoNothing: true,
-		}).Create(&tf)
-		if err = errors.Wrap(ret.Error, "save torrent file"); err != nil {
-			return
 		}
-		stat.TorrentFileCount += ret.RowsAffected
-		log.Trace().
-			Str("file", tf.Filename).Str("size", humanize.Bytes(uint64(tf.Size))).
+		if force {
+			onConflict = clause.OnConflict{
+				Columns: tt.ConflictColumns(),
+				DoUpdates: clause.AssignmentColumns([]string{
+					"hash_v2", "meta_version", "name", "total_file_size", "file_count", "piece_count", "is_dir",
+					"info_bytes", "piece_layers", "private", "source", "charset", "updated_at",
+				}),
+			}
+		}
+		ret := tx.Clauses(onConflict).Create(tt)
+		if err := errors.Wrap(ret.Error, "save torrent"); err != nil {
+			return err
+		}
+		stat.TorrentCount += ret.RowsAffected
+		log.Debug().
+			Str("name", tt.Name).Int("files", tt.FileCount).Str("size", humanize.Bytes(uint64(tt.TotalFileSize))).
 			Int64("affected", ret.RowsAffected).
-			Msg("index torrent file")
+			Msg("index torrent")
+		if ret.RowsAffected == 0 && !force {
+			var n int64
+			err := tx.Model(models.TorrentFile{}).Where(models.TorrentFile{TorrentHash: tt.Hash}).Count(&n).Error
+			if err = errors.Wrap(err, "count torrent file"); err != nil {
+				return err
+			}
+			if int(n) >= len(tfs) {
+				return nil
+			}
+			log.Warn().Str("hash", tt.Hash).Int64("files", n).Int("expected", len(tfs)).Msg("complete partial indexed torrent")
+		}
+		if ret.RowsAffected > 0 {
+			stat.TorrentFileTotalSize += tt.TotalFileSize
+		}
 	}
 
-	return
+	if force {
+		// rebuild files, derived files may changed, e.g. padding files are excluded
+		if err := tx.Where(models.TorrentFile{TorrentHash: tt.Hash}).Delete(&models.TorrentFile{}).Error; err != nil {
+			return errors.Wrap(err, "delete torrent file")
+		}
+	}
+	if len(tfs) == 0 {
+		return nil
+	}
+	ret := tx.Clauses(clause.OnConflict{
+		Columns:   tfs[0].ConflictColumns(),
+		DoNothing: true,
+	}).CreateInBatches(tfs, IndexFileBatchSize)
+	if err := errors.Wrap(ret.Error, "save torrent file"); err != nil {
+		return err
+	}
+	stat.TorrentFileCount += ret.RowsAffected
+	log.Debug().
+		Str("name", tt.Name).Int("files", len(tfs)).
+		Int64("affected", ret.RowsAffected).
+		Msg("index torrent files")
+	return nil
+}
+
+// metaConflict update derived columns of existing meta file when force
+func metaConflict(mf *models.MetaFile, force bool) clause.OnConflict {
+	if !force {
+		return clause.OnConflict{
+			Columns:   mf.ConflictColumns(),
+			DoNothing: true,
+		}
+	}
+	columns := []string{
+		"torrent_hash", "created_by", "creation_date", "comment", "encoding", "announce", "raw", "quarantined", "updated_at",
+	}
+	if mf.RawHash != "" {
+		columns = append(columns, "raw_bytes", "raw_hash", "raw_offset")
+	}
+	return clause.OnConflict{
+		Columns:   mf.ConflictColumns(),
+		DoUpdates: clause.AssignmentColumns(columns),
+	}
 }
 
 func nilString(v string) *string {
@@ -0,0 +1,78 @@
+package torrenti
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/wenerme/torrenti/pkg/torrenti/util/testx"
+	"github.com/xgfone/bt/bencode"
+	"gorm.io/gorm"
+)
+
+func newTestService(t *testing.T) *Service {
+	svc, err := NewIndexer(NewServiceOptions{DB: testx.NewMemoryDB(t)})
+	assert.NoError(t, err)
+	return svc
+}
+
+func newTestTorrent(t *testing.T, name string, n int) *Torrent {
+	var files []interface{}
+	for i := 0; i < n; i++ {
+		files = append(files, map[string]interface{}{"length": i + 1, "path": []string{fmt.Sprintf("%v.txt", i)}})
+	}
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":         name,
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 20)),
+			"files":        files,
+		},
+	})
+	assert.NoError(t, err)
+	return &Torrent{
+		Data:     data,
+		FileInfo: &util.File{Path: name + ".torrent", Length: int64(len(data))},
+	}
+}
+
+func TestIndexTorrent(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	tor := newTestTorrent(t, "test", IndexFileBatchSize+10)
+	stat, err := svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stat.MetaCount)
+	assert.Equal(t, int64(1), stat.TorrentCount)
+	assert.Equal(t, int64(IndexFileBatchSize+10), stat.TorrentFileCount)
+
+	// partial file list is completed
+	assert.NoError(t, svc.DB.Where("path like ?", "1%").Delete(models.TorrentFile{}).Error)
+	stat, err = svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+	assert.Zero(t, stat.MetaCount)
+	assert.Zero(t, stat.TorrentCount)
+	assert.NotZero(t, stat.TorrentFileCount)
+
+	var n int64
+	assert.NoError(t, svc.DB.Model(models.TorrentFile{}).Count(&n).Error)
+	assert.Equal(t, int64(IndexFileBatchSize+10), n)
+
+	// failed write leaves nothing
+	tor = newTestTorrent(t, "fail", 2)
+	assert.NoError(t, svc.DB.Callback().Create().Before("gorm:create").Register("test:fail", func(db *gorm.DB) {
+		if _, ok := db.Statement.Dest.([]models.TorrentFile); ok {
+			_ = db.AddError(fmt.Errorf("fail"))
+		}
+	}))
+	_, err = svc.IndexTorrent(ctx, tor)
+	assert.Error(t, err)
+	assert.NoError(t, svc.DB.Model(models.Torrent{}).Where("name = ?", "fail").Count(&n).Error)
+	assert.Zero(t, n)
+	assert.NoError(t, svc.DB.Model(models.MetaFile{}).Count(&n).Error)
+	assert.Equal(t, int64(1), n)
+}
@@ -0,0 +1,251 @@
+package torrenti
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xgfone/bt/bencode"
+	"github.com/xgfone/bt/metainfo"
+)
+
+// Info is the info dictionary with BEP 52 extension
+type Info struct {
+	metainfo.Info
+	MetaVersion int                    `bencode:"meta version,omitempty"` // BEP 52
+	FileTree    map[string]interface{} `bencode:"file tree,omitempty"`    // BEP 52
+	Private     int                    `bencode:"private,omitempty"`      // BEP 27
+	Source      string                 `bencode:"source,omitempty"`       // private tracker cross seeding tag
+	NameUTF8    string                 `bencode:"name.utf-8,omitempty"`   // utf-8 name when name is legacy encoded
+
+	attr  fileAttr   // single file attr
+	attrs []fileAttr // attr of v1 files
+}
+
+// fileAttr is BEP 47 extended file attributes
+type fileAttr struct {
+	Attr        string   `bencode:"attr,omitempty"`
+	SymlinkPath []string `bencode:"symlink path,omitempty"`
+	SHA1        string   `bencode:"sha1,omitempty"`
+	PathUTF8    []string `bencode:"path.utf-8,omitempty"`
+}
+
+// InfoFile is a file of torrent, merged from v1 files and v2 file tree
+type InfoFile struct {
+	Paths       []string
+	Length      int64
+	PiecesRoot  []byte   // v2 merkle root, empty for v1 only or empty file
+	Attr        string   // BEP 47 attributes, p=padding x=executable h=hidden l=symlink
+	SymlinkPath []string // BEP 47 symlink target, relative to torrent root
+	SHA1        []byte   // BEP 47 file sha1
+	PathsUTF8   []string // utf-8 paths of v1 file when paths are legacy encoded
+}
+
+// legacyPaddingPrefix is padding file name used by BitComet before BEP 47
+const legacyPaddingPrefix = "_____padding_file_"
+
+// IsPadding padding file align next file to piece boundary, has no real content
+func (f InfoFile) IsPadding() bool {
+	if strings.ContainsRune(f.Attr, 'p') {
+		return true
+	}
+	if len(f.Paths) == 2 && f.Paths[0] == ".pad" {
+		return true
+	}
+	return len(f.Paths) > 0 && strings.HasPrefix(f.Paths[len(f.Paths)-1], legacyPaddingPrefix)
+}
+
+func (f InfoFile) IsExecutable() bool {
+	return strings.ContainsRune(f.Attr, 'x')
+}
+
+func (f InfoFile) IsHidden() bool {
+	return strings.ContainsRune(f.Attr, 'h')
+}
+
+func (f InfoFile) IsSymlink() bool {
+	return strings.ContainsRune(f.Attr, 'l')
+}
+
+func (f InfoFile) Path() string {
+	return filepath.ToSlash(filepath.Join(f.Paths...))
+}
+
+func ParseInfo(b []byte) (info Info, err error) {
+	err = bencode.DecodeBytes(b, &info)
+	if err = errors.Wrap(err, "decode info"); err != nil {
+		return
+	}
+	// attributes are optional, malformed value is ignored
+	var attrs struct {
+		Attr        string     `bencode:"attr,omitempty"`
+		SymlinkPath []string   `bencode:"symlink path,omitempty"`
+		SHA1        string     `bencode:"sha1,omitempty"`
+		Files       []fileAttr `bencode:"files,omitempty"`
+	}
+	if bencode.DecodeBytes(b, &attrs) == nil {
+		info.attr = fileAttr{Attr: attrs.Attr, SymlinkPath: attrs.SymlinkPath, SHA1: attrs.SHA1}
+		info.attrs = attrs.Files
+	}
+	return
+}
+
+// IsV1 has v1 pieces, hybrid torrent is both v1 and v2
+func (info Info) IsV1() bool {
+	return info.MetaVersion < 2 || len(info.Pieces) > 0
+}
+
+func (info Info) IsV2() bool {
+	return info.MetaVersion == 2
+}
+
+// IsPrivate peer should only be obtained from the trackers, DHT and PEX are disabled
+func (info Info) IsPrivate() bool {
+	return info.Private == 1
+}
+
+func (info Info) IsHybrid() bool {
+	return info.IsV1() && info.IsV2()
+}
+
+func (info Info) IsDir() bool {
+	if info.IsV1() {
+		return info.Info.IsDir()
+	}
+	if len(info.FileTree) != 1 {
+		return true
+	}
+	node, ok := info.FileTree[info.Name].(map[string]interface{})
+	if !ok {
+		return true
+	}
+	_, ok = node[""]
+	return !ok
+}
+
+func (info Info) TotalLength() (n int64) {
+	if info.IsV1() {
+		return info.Info.TotalLength()
+	}
+	files, _ := info.FilesV2()
+	for _, f := range files {
+		n += f.Length
+	}
+	return
+}
+
+// ContentLength total length of files, padding files are excluded
+func (info Info) ContentLength() (n int64) {
+	files, _ := info.Files()
+	for _, f := range files {
+		if !f.IsPadding() {
+			n += f.Length
+		}
+	}
+	return
+}
+
+// CountPieces count v1 pieces or v2 pieces which are aligned to file
+func (info Info) CountPieces() (n int) {
+	if info.IsV1() {
+		return info.Info.CountPieces()
+	}
+	if info.PieceLength <= 0 {
+		return 0
+	}
+	files, _ := info.FilesV2()
+	for _, f := range files {
+		n += int((f.Length + info.PieceLength - 1) / info.PieceLength)
+	}
+	return
+}
+
+// FilesV2 walk the file tree in order, paths are relative to the torrent name for multi file torrent
+func (info Info) FilesV2() (files []InfoFile, err error) {
+	if !info.IsV2() {
+		return
+	}
+	err = walkFileTree(info.FileTree, nil, func(f InfoFile) {
+		files = append(files, f)
+	})
+	if err != nil {
+		return
+	}
+	if !info.IsDir() && len(files) == 1 {
+		files[0].Paths = nil
+	}
+	return
+}
+
+// Files return all files, v1 files have pieces root attached for hybrid torrent
+func (info Info) Files() (files []InfoFile, err error) {
+	if !info.IsV1() {
+		return info.FilesV2()
+	}
+	var roots map[string][]byte
+	if info.IsV2() {
+		var v2 []InfoFile
+		if v2, err = info.FilesV2(); err != nil {
+			return
+		}
+		roots = make(map[string][]byte, len(v2))
+		for _, f := range v2 {
+			roots[f.Path()] = f.PiecesRoot
+		}
+	}
+	for i, f := range info.Info.AllFiles() {
+		o := InfoFile{
+			Paths:  f.Paths,
+			Length: f.Length,
+		}
+		o.PiecesRoot = roots[o.Path()]
+		a := info.attr
+		if info.Info.IsDir() {
+			a = fileAttr{}
+			if i < len(info.attrs) {
+				a = info.attrs[i]
+			}
+		}
+		o.Attr, o.SymlinkPath = a.Attr, a.SymlinkPath
+		if info.Info.IsDir() {
+			o.PathsUTF8 = a.PathUTF8
+		}
+		if a.SHA1 != "" {
+			o.SHA1 = []byte(a.SHA1)
+		}
+		files = append(files, o)
+	}
+	return
+}
+
+func walkFileTree(tree map[string]interface{}, paths []string, cb func(f InfoFile)) error {
+	keys := make([]string, 0, len(tree))
+	for k := range tree {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		node, ok := tree[k].(map[string]interface{})
+		if !ok {
+			return errors.Errorf("invalid file tree node: %q", k)
+		}
+		if k == "" {
+			f := InfoFile{
+				Paths: append([]string(nil), paths...),
+			}
+			f.Length, _ = node["length"].(int64)
+			if v, ok := node["pieces root"].(string); ok {
+				f.PiecesRoot = []byte(v)
+			}
+			f.Attr, _ = node["attr"].(string)
+			cb(f)
+			continue
+		}
+		if err := walkFileTree(node, append(paths, k), cb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
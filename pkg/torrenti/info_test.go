@@ -0,0 +1,133 @@
+package torrenti
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
+)
+
+func TestParseInfo(t *testing.T) {
+	root := string(bytes.Repeat([]byte{1}, 32))
+	tree := map[string]interface{}{
+		"a.mkv": map[string]interface{}{
+			"": map[string]interface{}{"length": 10, "pieces root": root},
+		},
+		"sub": map[string]interface{}{
+			"b.srt": map[string]interface{}{
+				"": map[string]interface{}{"length": 5},
+			},
+		},
+	}
+
+	v2, err := bencode.EncodeBytes(map[string]interface{}{
+		"name":         "test",
+		"piece length": 16384,
+		"meta version": 2,
+		"file tree":    tree,
+	})
+	assert.NoError(t, err)
+	info, err := ParseInfo(v2)
+	assert.NoError(t, err)
+	assert.True(t, info.IsV2())
+	assert.False(t, info.IsV1())
+	assert.True(t, info.IsDir())
+	assert.Equal(t, int64(15), info.TotalLength())
+	assert.Equal(t, 2, info.CountPieces())
+	files, err := info.Files()
+	assert.NoError(t, err)
+	assert.Equal(t, []InfoFile{
+		{Paths: []string{"a.mkv"}, Length: 10, PiecesRoot: []byte(root)},
+		{Paths: []string{"sub", "b.srt"}, Length: 5},
+	}, files)
+
+	hybrid, err := bencode.EncodeBytes(map[string]interface{}{
+		"name":         "test",
+		"piece length": 16384,
+		"meta version": 2,
+		"file tree":    tree,
+		"pieces":       string(bytes.Repeat([]byte{2}, 20)),
+		"files": []interface{}{
+			map[string]interface{}{"length": 10, "path": []string{"a.mkv"}},
+			map[string]interface{}{"length": 5, "path": []string{"sub", "b.srt"}},
+		},
+	})
+	assert.NoError(t, err)
+	info, err = ParseInfo(hybrid)
+	assert.NoError(t, err)
+	assert.True(t, info.IsHybrid())
+	files, err = info.Files()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(root), files[0].PiecesRoot)
+	assert.Empty(t, files[1].PiecesRoot)
+
+	single, err := bencode.EncodeBytes(map[string]interface{}{
+		"name":         "a.mkv",
+		"piece length": 16384,
+		"meta version": 2,
+		"file tree": map[string]interface{}{
+			"a.mkv": map[string]interface{}{
+				"": map[string]interface{}{"length": 10, "pieces root": root},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	info, err = ParseInfo(single)
+	assert.NoError(t, err)
+	assert.False(t, info.IsDir())
+	files, err = info.Files()
+	assert.NoError(t, err)
+	assert.Equal(t, []InfoFile{{Length: 10, PiecesRoot: []byte(root)}}, files)
+}
+
+func TestFileAttr(t *testing.T) {
+	svc := newTestService(t)
+	sha1 := string(bytes.Repeat([]byte{3}, 20))
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":         "test",
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 40)),
+			"files": []interface{}{
+				map[string]interface{}{"length": 10, "path": []string{"a.sh"}, "attr": "xh", "sha1": sha1},
+				map[string]interface{}{"length": 16374, "path": []string{".pad", "16374"}, "attr": "p"},
+				map[string]interface{}{"length": 0, "path": []string{"b"}, "attr": "l", "symlink path": []string{"a.sh"}},
+				map[string]interface{}{"length": 5, "path": []string{"c.txt"}},
+				map[string]interface{}{"length": 10, "path": []string{"_____padding_file_0_"}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	tor := &Torrent{Data: data, FileInfo: &util.File{Path: "test.torrent"}}
+	assert.NoError(t, tor.Load())
+	files, err := tor.Info.Files()
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true, false, false, true}, lo.Map(files, func(f InfoFile, i int) bool {
+		return f.IsPadding()
+	}))
+	assert.True(t, files[0].IsExecutable())
+	assert.True(t, files[0].IsHidden())
+	assert.Equal(t, []byte(sha1), files[0].SHA1)
+	assert.True(t, files[2].IsSymlink())
+	assert.Equal(t, []string{"a.sh"}, files[2].SymlinkPath)
+	assert.False(t, Lint(tor).HasError())
+
+	stat, err := svc.IndexTorrent(context.Background(), tor)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), stat.TorrentFileCount)
+	assert.Equal(t, int64(15), stat.TorrentFileTotalSize)
+	var tfs []models.TorrentFile
+	assert.NoError(t, svc.DB.Order("path").Find(&tfs).Error)
+	assert.Equal(t, []string{"a.sh", "b", "c.txt"}, lo.Map(tfs, func(f models.TorrentFile, i int) string {
+		return f.Path
+	}))
+	assert.Equal(t, "xh", tfs[0].Attr)
+	assert.Equal(t, hex.EncodeToString([]byte(sha1)), tfs[0].SHA1)
+	assert.Equal(t, "a.sh", tfs[1].SymlinkPath)
+}
@@ -0,0 +1,209 @@
+package torrenti
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// LintMode decide how invalid torrent is handled when indexing
+type LintMode string
+
+const (
+	LintModeRecord     LintMode = "record"     // index and record findings
+	LintModeReject     LintMode = "reject"     // reject torrent has error finding
+	LintModeQuarantine LintMode = "quarantine" // keep meta file and findings only
+)
+
+func ParseLintMode(s string) (LintMode, error) {
+	switch v := LintMode(strings.ToLower(s)); v {
+	case "":
+		return LintModeRecord, nil
+	case LintModeRecord, LintModeReject, LintModeQuarantine:
+		return v, nil
+	default:
+		return "", errors.Errorf("invalid lint mode: %q", s)
+	}
+}
+
+type Finding struct {
+	Severity Severity
+	Code     string
+	Path     string // file path related to the finding
+	Message  string
+}
+
+func (f Finding) String() string {
+	if f.Path != "" {
+		return fmt.Sprintf("%s %s %q: %s", f.Severity, f.Code, f.Path, f.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", f.Severity, f.Code, f.Message)
+}
+
+type Findings []Finding
+
+func (fs Findings) HasError() bool {
+	for _, v := range fs {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidTorrentError is returned when torrent rejected by lint
+type InvalidTorrentError struct {
+	Findings Findings
+}
+
+func (e *InvalidTorrentError) Error() string {
+	for _, v := range e.Findings {
+		if v.Severity == SeverityError {
+			return "invalid torrent: " + v.String()
+		}
+	}
+	return "invalid torrent"
+}
+
+// Lint check loaded torrent
+func Lint(t *Torrent) (out Findings) {
+	add := func(s Severity, code string, p string, msg string, args ...interface{}) {
+		out = append(out, Finding{Severity: s, Code: code, Path: p, Message: fmt.Sprintf(msg, args...)})
+	}
+	if t.Info == nil {
+		add(SeverityError, "no-info", "", "info not loaded")
+		return
+	}
+	info := t.Info
+
+	switch {
+	case strings.TrimSpace(info.Name) == "":
+		add(SeverityError, "empty-name", "", "name is empty")
+	case info.Name == "." || info.Name == ".." || strings.ContainsAny(info.Name, "/\\\x00"):
+		add(SeverityError, "unsafe-path", info.Name, "unsafe name")
+	}
+	if !utf8.ValidString(info.Name) {
+		add(SeverityWarning, "invalid-utf8", "", "name is not valid utf-8")
+	}
+
+	if info.PieceLength <= 0 {
+		add(SeverityError, "piece-length", "", "invalid piece length %v", info.PieceLength)
+	} else {
+		if info.PieceLength&(info.PieceLength-1) != 0 {
+			add(SeverityWarning, "piece-length", "", "piece length %v is not power of two", info.PieceLength)
+		}
+		if info.IsV2() && info.PieceLength < 16*1024 {
+			add(SeverityError, "piece-length", "", "v2 piece length %v less than 16KiB", info.PieceLength)
+		}
+	}
+	if info.IsV1() && len(info.Info.Files) > 0 && info.Length > 0 {
+		add(SeverityError, "length-and-files", "", "both length and files present")
+	}
+
+	files, err := info.Files()
+	if err != nil {
+		add(SeverityError, "file-tree", "", "%v", err)
+		return
+	}
+	if len(files) == 0 {
+		add(SeverityError, "no-files", "", "no files")
+	}
+
+	seen := map[string]bool{}
+	folded := map[string]bool{}
+	var total int64
+	for _, f := range files {
+		p := f.Path()
+		if f.Length < 0 {
+			add(SeverityError, "file-length", p, "negative length %v", f.Length)
+		}
+		total += f.Length
+		if info.IsDir() {
+			if len(f.Paths) == 0 {
+				add(SeverityError, "unsafe-path", p, "empty path")
+			}
+			for _, c := range f.Paths {
+				if reason := unsafePathComponent(c); reason != "" {
+					add(SeverityError, "unsafe-path", p, "%s", reason)
+					break
+				}
+			}
+			if !utf8.ValidString(p) {
+				add(SeverityWarning, "invalid-utf8", p, "path is not valid utf-8")
+			}
+		}
+		if f.IsPadding() {
+			// hybrid torrent may repeat same padding file
+			continue
+		}
+		for _, c := range f.SymlinkPath {
+			if reason := unsafePathComponent(c); reason != "" {
+				add(SeverityError, "unsafe-path", p, "symlink %s", reason)
+				break
+			}
+		}
+		if len(f.SHA1) > 0 && len(f.SHA1) != 20 {
+			add(SeverityWarning, "file-sha1", p, "invalid sha1 length %v", len(f.SHA1))
+		}
+		if seen[p] {
+			add(SeverityError, "duplicate-path", p, "duplicate path")
+		} else if lp := strings.ToLower(p); folded[lp] {
+			add(SeverityWarning, "duplicate-path", p, "path differ only in case")
+		}
+		seen[p] = true
+		folded[strings.ToLower(p)] = true
+
+		if info.IsV2() && f.Length > 0 && len(f.PiecesRoot) != 32 {
+			add(SeverityError, "pieces-root", p, "missing or invalid pieces root")
+		}
+	}
+	if total == 0 && len(files) > 0 {
+		add(SeverityWarning, "empty", "", "total size is zero")
+	}
+
+	if info.IsV1() && info.PieceLength > 0 {
+		if n, expected := len(info.Pieces), int((info.Info.TotalLength()+info.PieceLength-1)/info.PieceLength); n != expected {
+			add(SeverityError, "pieces", "", "got %v pieces, expected %v for total size %v", n, expected, info.Info.TotalLength())
+		}
+	}
+	if info.IsV2() && info.PieceLength > 0 {
+		for _, f := range files {
+			if f.Length > info.PieceLength && len(t.PieceLayers) == 0 {
+				add(SeverityError, "piece-layers", "", "missing piece layers")
+				break
+			}
+		}
+	}
+
+	if t.Meta != nil && len(Trackers(t.Meta)) == 0 && len(t.Meta.Nodes) == 0 {
+		add(SeverityInfo, "no-tracker", "", "no valid tracker or dht node")
+	}
+	return
+}
+
+// unsafePathComponent return reason if component may escape the download dir
+func unsafePathComponent(c string) string {
+	switch {
+	case c == "":
+		return "empty path component"
+	case c == "." || c == "..":
+		return "relative path component " + c
+	case strings.ContainsAny(c, "/\\"):
+		return "path separator in component"
+	case strings.ContainsRune(c, 0):
+		return "nul in path component"
+	case len(c) >= 2 && c[1] == ':' && (c[0]|0x20) >= 'a' && (c[0]|0x20) <= 'z':
+		return "absolute path component"
+	}
+	return ""
+}
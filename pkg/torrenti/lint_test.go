@@ -0,0 +1,104 @@
+package torrenti
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
+)
+
+func TestLint(t *testing.T) {
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":         "bad",
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 40)),
+			"files": []interface{}{
+				map[string]interface{}{"length": 1, "path": []string{"a"}},
+				map[string]interface{}{"length": 1, "path": []string{"a"}},
+				map[string]interface{}{"length": 1, "path": []string{"A"}},
+				map[string]interface{}{"length": 1, "path": []string{"..", "b"}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	tor := &Torrent{Data: data, FileInfo: &util.File{Path: "bad.torrent"}}
+	assert.NoError(t, tor.Load())
+
+	var codes []string
+	for _, v := range Lint(tor) {
+		codes = append(codes, string(v.Severity)+" "+v.Code+" "+v.Path)
+	}
+	assert.Equal(t, []string{
+		"error duplicate-path a",
+		"warning duplicate-path A",
+		"error unsafe-path ../b",
+		"error pieces ",
+		"info no-tracker ",
+	}, codes)
+
+	svc := newTestService(t)
+	ctx := context.Background()
+	_, err = svc.IndexTorrent(ctx, tor, func(o *IndexTorrentOptions) {
+		o.Lint = LintModeReject
+	})
+	var ite *InvalidTorrentError
+	assert.True(t, errors.As(err, &ite))
+
+	stat, err := svc.IndexTorrent(ctx, tor, func(o *IndexTorrentOptions) {
+		o.Lint = LintModeQuarantine
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stat.QuarantinedCount)
+	assert.Zero(t, stat.TorrentCount)
+
+	var n int64
+	assert.NoError(t, svc.DB.Model(models.LintFinding{}).Count(&n).Error)
+	// info finding is not stored
+	assert.Equal(t, int64(4), n)
+
+	mfs, err := svc.FindMetaFiles(ctx, tor.Hash.HexHash())
+	assert.NoError(t, err)
+	assert.Len(t, mfs, 1)
+	assert.True(t, mfs[0].Quarantined)
+	stored, err := svc.StoredTorrent(ctx, mfs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, tor.Data, stored.Data)
+
+	// indexed after lint mode changed
+	stat, err = svc.IndexTorrent(ctx, tor, func(o *IndexTorrentOptions) {
+		o.Lint = LintModeRecord
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stat.TorrentCount)
+	mfs, err = svc.FindMetaFiles(ctx, tor.Hash.HexHash())
+	assert.NoError(t, err)
+	assert.Len(t, mfs, 1)
+	assert.False(t, mfs[0].Quarantined)
+}
+
+func TestLintName(t *testing.T) {
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":         "\xff/../x",
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 20)),
+			"length":       1,
+		},
+	})
+	assert.NoError(t, err)
+	tor := &Torrent{Data: data, FileInfo: &util.File{Path: "name.torrent"}}
+	assert.NoError(t, tor.Load())
+
+	var codes []string
+	for _, v := range Lint(tor) {
+		if v.Code == "unsafe-path" || v.Code == "invalid-utf8" {
+			codes = append(codes, string(v.Severity)+" "+v.Code)
+		}
+	}
+	assert.Equal(t, []string{"error unsafe-path", "warning invalid-utf8"}, codes)
+}
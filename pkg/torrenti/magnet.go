@@ -0,0 +1,109 @@
+package torrenti
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"gorm.io/gorm"
+)
+
+// NewMagnet build magnet of loaded torrent
+func NewMagnet(t *Torrent) (m magnet.Magnet) {
+	m.Hash = t.Hash
+	m.HashV2 = t.HashV2
+	if info := t.Info; info != nil {
+		if !info.IsV1() {
+			m.Hash, m.HashV2 = t.HashV2, magnet.Hash{}
+		}
+		m.DisplayName = info.Name
+		m.Length = info.ContentLength()
+	}
+	if mi := t.Meta; mi != nil {
+		for _, v := range Trackers(mi) {
+			m.Trackers = append(m.Trackers, v.URL)
+		}
+		m.WebSeeds = uniqueStrings(mi.URLList)
+	}
+	return
+}
+
+// StoredMagnet build magnet of stored torrent
+func StoredMagnet(t *models.Torrent, trackers []string, webSeeds []string) (m magnet.Magnet, err error) {
+	if m.Hash, err = magnet.ParseHash(t.Hash); err != nil {
+		return
+	}
+	if t.HashV2 != "" {
+		if m.HashV2, err = magnet.ParseHash(t.HashV2); err != nil {
+			return
+		}
+		if bytes.HasPrefix(m.HashV2.Digest, m.Hash.Digest) {
+			// v2 only, hash is truncated
+			m.Hash, m.HashV2 = m.HashV2, magnet.Hash{}
+		}
+	}
+	m.DisplayName = t.Name
+	m.Length = t.TotalFileSize
+	m.Trackers = trackers
+	m.WebSeeds = webSeeds
+	return
+}
+
+// LoadMagnets build magnets of stored torrents, trackers and web seeds are merged from all meta files
+func LoadMagnets(ctx context.Context, db *gorm.DB, torrents []*models.Torrent) (out map[string]magnet.Magnet, err error) {
+	out = make(map[string]magnet.Magnet, len(torrents))
+	if len(torrents) == 0 {
+		return
+	}
+	hashes := make([]string, 0, len(torrents))
+	for _, v := range torrents {
+		hashes = append(hashes, v.Hash)
+	}
+	db = db.WithContext(ctx)
+
+	var tts []models.TorrentTracker
+	err = db.Select("torrent_hash", "tracker_url").Where("torrent_hash in (?)", hashes).Order("tier, id").Find(&tts).Error
+	if err = errors.Wrap(err, "find torrent trackers"); err != nil {
+		return
+	}
+	trackers := map[string][]string{}
+	for _, v := range tts {
+		trackers[v.TorrentHash] = append(trackers[v.TorrentHash], v.TrackerURL)
+	}
+
+	seeds, err := LoadWebSeeds(ctx, db, hashes)
+	if err != nil {
+		return
+	}
+	webSeeds := map[string][]string{}
+	for k, v := range seeds {
+		for _, ws := range v {
+			if ws.Type == WebSeedTypeURLList {
+				webSeeds[k] = append(webSeeds[k], ws.URL)
+			}
+		}
+	}
+
+	for _, v := range torrents {
+		var m magnet.Magnet
+		if m, err = StoredMagnet(v, trackers[v.Hash], webSeeds[v.Hash]); err != nil {
+			return nil, errors.Wrapf(err, "magnet of %s", v.Hash)
+		}
+		out[v.Hash] = m
+	}
+	return
+}
+
+func uniqueStrings(s []string) (out []string) {
+	seen := make(map[string]bool, len(s))
+	for _, v := range s {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return
+}
@@ -0,0 +1,41 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
+)
+
+func TestMagnet(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"announce":      "udp://a:1",
+		"announce-list": [][]string{{"udp://a:1"}, {"http://b/announce"}},
+		"url-list":      "http://c/",
+		"info": map[string]interface{}{
+			"name":         "test",
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 20)),
+			"length":       10,
+		},
+	})
+	assert.NoError(t, err)
+	tor := &Torrent{Data: data, FileInfo: &util.File{Path: "test.torrent"}}
+	assert.NoError(t, tor.Load())
+	m := NewMagnet(tor)
+	assert.Equal(t, "magnet:?xt="+tor.Hash.String()+"&dn=test&xl=10&tr=udp%3A%2F%2Fa%3A1&tr=http%3A%2F%2Fb%2Fannounce&ws=http%3A%2F%2Fc%2F", m.String())
+
+	_, err = svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+	var out []*models.Torrent
+	assert.NoError(t, svc.DB.Find(&out).Error)
+	ms, err := LoadMagnets(ctx, svc.DB, out)
+	assert.NoError(t, err)
+	assert.Equal(t, m.String(), ms[tor.Hash.String()].String())
+}
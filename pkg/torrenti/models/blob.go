@@ -0,0 +1,15 @@
+package models
+
+import "gorm.io/gorm/clause"
+
+// MetaBlob is compressed original bytes of meta file, shared by meta files with same outer dict
+type MetaBlob struct {
+	Model
+	Hash string `gorm:"unique"` // sha256 of uncompressed data
+	Size int64  // uncompressed size
+	Data []byte // zstd compressed
+}
+
+func (MetaBlob) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "hash"}}
+}
@@ -0,0 +1,14 @@
+package models
+
+import "gorm.io/gorm/clause"
+
+// Checkpoint is the position of resumable job
+type Checkpoint struct {
+	Model
+	Name     string `gorm:"unique"`
+	Position uint
+}
+
+func (Checkpoint) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "name"}}
+}
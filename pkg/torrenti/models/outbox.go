@@ -0,0 +1,7 @@
+package models
+
+// SearchOutbox is a torrent pending to sync to search index, written in same transaction of indexed data
+type SearchOutbox struct {
+	Model
+	TorrentHash string
+}
@@ -0,0 +1,16 @@
+package models
+
+import "gorm.io/gorm/clause"
+
+// Tombstone mark deleted torrent, tombstoned torrent will not be indexed again
+type Tombstone struct {
+	Model
+	TorrentHash string `gorm:"unique"`
+	HashV2      string `gorm:"index"`
+	Name        string
+	Reason      string
+}
+
+func (Tombstone) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "torrent_hash"}}
+}
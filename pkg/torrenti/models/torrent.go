@@ -18,7 +18,10 @@ type MetaFile struct {
 	Size         int64   `gorm:"index"`
 	Referer      *string `gorm:"index"`
 	Raw          datatypes.JSON
-	RawBytes     []byte
+	RawBytes     []byte // legacy original bytes of quarantined meta file
+	RawHash      string `gorm:"index"` // hash of MetaBlob with original bytes
+	RawOffset    int    // offset of removed info value in blob, 0 if blob is the whole file
+	Quarantined  bool   `gorm:"index"` // invalid torrent, only meta file and findings are stored
 
 	Torrent *Torrent `gorm:"foreignKey:TorrentHash;references:Hash"`
 }
@@ -30,29 +33,107 @@ type TorrentFile struct {
 	Path        string `gorm:"uniqueIndex:torrent_files_torrent_hash_path"`
 	Filename    string
 	Ext         string
+	PiecesRoot  string `gorm:"index"` // hex encoded v2 merkle root
+	Attr        string // BEP 47 attributes, x=executable h=hidden l=symlink
+	SymlinkPath string // BEP 47 symlink target joined by /
+	SHA1        string // hex encoded BEP 47 file sha1
 }
 
 type Torrent struct {
 	Model
 	Hash          string `gorm:"unique"`
-	Name          string
-	TotalFileSize int64 `gorm:"index"`
-	FileCount     int
+	HashV2        string `gorm:"index"` // v2 btmh hash for v2 and hybrid torrent
+	MetaVersion   int
+	Name          string // decoded utf-8 name
+	Charset       string // charset of legacy encoded name and paths, empty if utf-8
+	TotalFileSize int64  `gorm:"index"` // padding files are excluded
+	FileCount     int    // padding files are excluded
 	PieceCount    int
 	IsDir         bool
 	InfoBytes     []byte
+	PieceLayers   []byte
+	Private       bool   `gorm:"index"` // BEP 27 private torrent
+	Source        string `gorm:"index"` // info source tag
+	HasWebSeed    bool   `gorm:"index"` // has http web seed from any meta file
+}
+
+// TorrentWebSeed is a http web seed, merged from all meta files of torrent
+type TorrentWebSeed struct {
+	Model
+	TorrentHash string `gorm:"uniqueIndex:torrent_web_seeds_torrent_hash_url_type"`
+	URL         string `gorm:"uniqueIndex:torrent_web_seeds_torrent_hash_url_type"`
+	Type        string `gorm:"uniqueIndex:torrent_web_seeds_torrent_hash_url_type"` // url-list or httpseeds
+
+	Torrent *Torrent `gorm:"foreignKey:TorrentHash;references:Hash"`
+}
+
+// TorrentNode is a dht bootstrap node
+type TorrentNode struct {
+	Model
+	TorrentHash string `gorm:"uniqueIndex:torrent_nodes_torrent_hash_host_port"`
+	Host        string `gorm:"uniqueIndex:torrent_nodes_torrent_hash_host_port"`
+	Port        int    `gorm:"uniqueIndex:torrent_nodes_torrent_hash_host_port"`
+
+	Torrent *Torrent `gorm:"foreignKey:TorrentHash;references:Hash"`
+}
+
+// TorrentDuplicate is a member of duplicate group, torrents in group have same content
+type TorrentDuplicate struct {
+	Model
+	TorrentHash string `gorm:"unique"`
+	GroupHash   string `gorm:"index"` // hash of first indexed torrent in group
+	Match       string // how the torrent matched, paths or sizes
+
+	Torrent *Torrent `gorm:"foreignKey:TorrentHash;references:Hash"`
+}
+
+// LintFinding is a finding of torrent validation
+type LintFinding struct {
+	Model
+	ContentHash string `gorm:"uniqueIndex:lint_findings_content_hash_code_path"`
+	TorrentHash string `gorm:"index"`
+	Severity    string `gorm:"index"`
+	Code        string `gorm:"uniqueIndex:lint_findings_content_hash_code_path;index"`
+	Path        string `gorm:"uniqueIndex:lint_findings_content_hash_code_path"`
+	Message     string
 }
 
 type Tracker struct {
 	Model
 	URL      string `gorm:"unique"`
-	Protocol string
+	Protocol string `gorm:"index"`
+}
+
+type TorrentTracker struct {
+	Model
+	TorrentHash string `gorm:"uniqueIndex:torrent_trackers_torrent_hash_tracker_url"`
+	TrackerURL  string `gorm:"uniqueIndex:torrent_trackers_torrent_hash_tracker_url;index"`
+	Tier        int
+
+	Torrent *Torrent `gorm:"foreignKey:TorrentHash;references:Hash"`
+	Tracker *Tracker `gorm:"foreignKey:TrackerURL;references:URL"`
+}
+
+func (LintFinding) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "content_hash"}, {Name: "code"}, {Name: "path"}}
 }
 
 func (Tracker) ConflictColumns() []clause.Column {
 	return []clause.Column{{Name: "url"}}
 }
 
+func (TorrentTracker) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "torrent_hash"}, {Name: "tracker_url"}}
+}
+
+func (TorrentWebSeed) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "torrent_hash"}, {Name: "url"}, {Name: "type"}}
+}
+
+func (TorrentNode) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "torrent_hash"}, {Name: "host"}, {Name: "port"}}
+}
+
 func (Torrent) ConflictColumns() []clause.Column {
 	return []clause.Column{{Name: "hash"}}
 }
@@ -64,3 +145,7 @@ func (TorrentFile) ConflictColumns() []clause.Column {
 func (MetaFile) ConflictColumns() []clause.Column {
 	return []clause.Column{{Name: "content_hash"}}
 }
+
+func (TorrentDuplicate) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "torrent_hash"}}
+}
@@ -0,0 +1,16 @@
+package models
+
+import "gorm.io/gorm/clause"
+
+// WatchFile is a processed file of watch folder
+type WatchFile struct {
+	Model
+	ContentHash string `gorm:"unique"`
+	Filename    string
+	Status      string `gorm:"index"` // done or failed
+	Error       string
+}
+
+func (WatchFile) ConflictColumns() []clause.Column {
+	return []clause.Column{{Name: "content_hash"}}
+}
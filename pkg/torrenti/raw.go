@@ -0,0 +1,189 @@
+package torrenti
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	rawEncoder, _ = zstd.NewWriter(nil)
+	rawDecoder, _ = zstd.NewReader(nil)
+)
+
+// splitRaw remove top level info value from data, offset is 0 if info not found or not match
+func splitRaw(data []byte, info []byte) (outer []byte, offset int) {
+	start, end, err := bencodeDictValue(data, "info")
+	if err != nil || start == 0 || !bytes.Equal(data[start:end], info) {
+		return data, 0
+	}
+	outer = make([]byte, 0, len(data)-(end-start))
+	outer = append(outer, data[:start]...)
+	outer = append(outer, data[end:]...)
+	return outer, start
+}
+
+// saveRawTx store original bytes of meta file to shared blob
+func saveRawTx(tx *gorm.DB, mf *models.MetaFile, data []byte, info []byte) error {
+	outer, offset := splitRaw(data, info)
+	blob := &models.MetaBlob{
+		Hash: util.ContentHashBytes(outer),
+		Size: int64(len(outer)),
+		Data: rawEncoder.EncodeAll(outer, nil),
+	}
+	ret := tx.Clauses(clause.OnConflict{
+		Columns:   blob.ConflictColumns(),
+		DoNothing: true,
+	}).Create(blob)
+	if err := errors.Wrap(ret.Error, "save meta blob"); err != nil {
+		return err
+	}
+	mf.RawHash = blob.Hash
+	mf.RawOffset = offset
+	mf.RawBytes = nil
+	// fill meta file indexed before raw storage
+	err := tx.Model(&models.MetaFile{}).
+		Where("content_hash = ? and (raw_hash is null or raw_hash = '')", mf.ContentHash).
+		Updates(map[string]interface{}{"raw_hash": mf.RawHash, "raw_offset": mf.RawOffset, "raw_bytes": nil}).Error
+	return errors.Wrap(err, "update meta raw")
+}
+
+// deleteRawTx delete blobs no longer referenced by meta file
+func deleteRawTx(tx *gorm.DB, hashes []string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+	err := tx.Where("hash in (?) and hash not in (?)", hashes,
+		tx.Model(&models.MetaFile{}).Select("raw_hash").Where("raw_hash in (?)", hashes)).
+		Delete(&models.MetaBlob{}).Error
+	return errors.Wrap(err, "delete meta blob")
+}
+
+// LoadTorrentData return original .torrent of meta file, sha256 of data is verified against content hash
+//
+// meta file indexed before raw storage is rebuilt from meta raw and torrent info, exact is false if rebuilt data not match the content hash.
+func LoadTorrentData(ctx context.Context, db *gorm.DB, mf *models.MetaFile) (data []byte, exact bool, err error) {
+	db = db.WithContext(ctx)
+	switch {
+	case mf.RawHash != "":
+		var blob models.MetaBlob
+		if err = db.Where(models.MetaBlob{Hash: mf.RawHash}).Limit(1).Find(&blob).Error; err != nil {
+			return nil, false, errors.Wrap(err, "find meta blob")
+		}
+		if blob.ID == 0 {
+			return nil, false, errors.Errorf("meta blob not found: %s", mf.RawHash)
+		}
+		if data, err = rawDecoder.DecodeAll(blob.Data, nil); err != nil {
+			return nil, false, errors.Wrap(err, "decode meta blob")
+		}
+		if mf.RawOffset > 0 {
+			if mf.RawOffset > len(data) {
+				return nil, false, errors.Errorf("invalid raw offset %v of %v bytes", mf.RawOffset, len(data))
+			}
+			if mf.Torrent == nil {
+				var t models.Torrent
+				if err = db.Where(models.Torrent{Hash: mf.TorrentHash}).Limit(1).Find(&t).Error; err != nil {
+					return nil, false, errors.Wrap(err, "find torrent")
+				}
+				if t.ID == 0 {
+					return nil, false, errors.Errorf("torrent not found: %s", mf.TorrentHash)
+				}
+				mf.Torrent = &t
+			}
+			info := mf.Torrent.InfoBytes
+			out := make([]byte, 0, len(data)+len(info))
+			out = append(out, data[:mf.RawOffset]...)
+			out = append(out, info...)
+			data = append(out, data[mf.RawOffset:]...)
+		}
+	case len(mf.RawBytes) > 0:
+		data = mf.RawBytes
+	default:
+		if data, err = BuildTorrentData(mf); err != nil {
+			return nil, false, err
+		}
+		return data, util.ContentHashBytes(data) == mf.ContentHash, nil
+	}
+	if h := util.ContentHashBytes(data); h != mf.ContentHash {
+		return nil, false, errors.Errorf("content hash mismatch: expected %s got %s", mf.ContentHash, h)
+	}
+	return data, true, nil
+}
+
+// bencodeDictValue find value span of key in top level dict
+func bencodeDictValue(data []byte, key string) (start, end int, err error) {
+	if len(data) == 0 || data[0] != 'd' {
+		return 0, 0, errors.New("not a dict")
+	}
+	i := 1
+	for i < len(data) && data[i] != 'e' {
+		if data[i] < '0' || data[i] > '9' {
+			return 0, 0, errors.Errorf("invalid dict key at %v", i)
+		}
+		ke, err := bencodeSkip(data, i)
+		if err != nil {
+			return 0, 0, err
+		}
+		ve, err := bencodeSkip(data, ke)
+		if err != nil {
+			return 0, 0, err
+		}
+		if k := data[i+bytes.IndexByte(data[i:ke], ':')+1 : ke]; string(k) == key {
+			return ke, ve, nil
+		}
+		i = ve
+	}
+	return 0, 0, errors.Errorf("key not found: %s", key)
+}
+
+// bencodeSkip return end of value starting at i
+func bencodeSkip(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, errors.New("unexpected end")
+	}
+	switch c := data[i]; {
+	case c == 'i':
+		j := bytes.IndexByte(data[i:], 'e')
+		if j < 0 {
+			return 0, errors.Errorf("invalid int at %v", i)
+		}
+		return i + j + 1, nil
+	case c == 'l' || c == 'd':
+		i++
+		for i < len(data) && data[i] != 'e' {
+			var err error
+			if i, err = bencodeSkip(data, i); err != nil {
+				return 0, err
+			}
+		}
+		if i >= len(data) {
+			return 0, errors.New("unexpected end")
+		}
+		return i + 1, nil
+	case c >= '0' && c <= '9':
+		j := bytes.IndexByte(data[i:], ':')
+		if j < 0 {
+			return 0, errors.Errorf("invalid string at %v", i)
+		}
+		n := 0
+		for _, d := range data[i : i+j] {
+			if d < '0' || d > '9' || n > len(data) {
+				return 0, errors.Errorf("invalid string length at %v", i)
+			}
+			n = n*10 + int(d-'0')
+		}
+		end := i + j + 1 + n
+		if end > len(data) {
+			return 0, errors.New("unexpected end")
+		}
+		return end, nil
+	default:
+		return 0, errors.Errorf("invalid value at %v", i)
+	}
+}
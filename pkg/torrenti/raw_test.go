@@ -0,0 +1,67 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+func TestLoadTorrentData(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	var tors []*Torrent
+	for _, name := range []string{"a", "b"} {
+		src := newTestTorrent(t, name, 2).Data
+		start, end, err := bencodeDictValue(src, "info")
+		assert.NoError(t, err)
+		infoBytes := src[start:end]
+		// non utf-8 comment and binary node host
+		data := []byte("d7:comment3:\xff\xfe\x00" + "4:info" + string(infoBytes) + "5:nodesll4:\x01\x02\x03\xffi6881eeee")
+		tor := &Torrent{Data: data, FileInfo: &util.File{Path: name + ".torrent", Length: int64(len(data))}}
+		_, err = svc.IndexTorrent(ctx, tor)
+		assert.NoError(t, err)
+		tors = append(tors, tor)
+	}
+
+	var n int64
+	assert.NoError(t, svc.DB.Model(models.MetaBlob{}).Count(&n).Error)
+	assert.Equal(t, int64(1), n, "same outer dict should be shared")
+
+	for _, tor := range tors {
+		mfs, err := svc.FindMetaFiles(ctx, tor.Hash.HexHash())
+		assert.NoError(t, err)
+		assert.Len(t, mfs, 1)
+		assert.NotEmpty(t, mfs[0].RawHash)
+		assert.NotZero(t, mfs[0].RawOffset)
+
+		data, exact, err := LoadTorrentData(ctx, svc.DB, mfs[0])
+		assert.NoError(t, err)
+		assert.True(t, exact)
+		assert.Equal(t, tor.Data, data)
+
+		mfs[0].ContentHash = util.ContentHashBytes(nil)
+		_, _, err = LoadTorrentData(ctx, svc.DB, mfs[0])
+		assert.Error(t, err)
+
+		// legacy meta file is rebuilt
+		mf := *mfs[0]
+		mf.RawHash = ""
+		data, exact, err = LoadTorrentData(ctx, svc.DB, &mf)
+		assert.NoError(t, err)
+		assert.False(t, exact)
+		assert.NotEmpty(t, data)
+	}
+
+	_, err := svc.DeleteTorrent(ctx, &DeleteTorrentRequest{Hashes: []string{tors[0].Hash.String()}})
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DB.Model(models.MetaBlob{}).Count(&n).Error)
+	assert.Equal(t, int64(1), n)
+	_, err = svc.DeleteTorrent(ctx, &DeleteTorrentRequest{Hashes: []string{tors[1].Hash.String()}})
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DB.Model(models.MetaBlob{}).Count(&n).Error)
+	assert.Zero(t, n)
+}
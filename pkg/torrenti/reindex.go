@@ -0,0 +1,104 @@
+package torrenti
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"gorm.io/gorm/clause"
+)
+
+type ReindexOptions struct {
+	BatchSize  int
+	Checkpoint string // checkpoint name, default to reindex
+	Restart    bool   // ignore saved checkpoint
+	OnProgress func(p *ReindexProgress)
+}
+
+type ReindexProgress struct {
+	Position uint // last processed meta file id
+	Total    int64
+	Done     int64
+	Failed   int64
+}
+
+// Reindex re-derive torrent tables from stored meta files, no network access is required
+//
+// checkpoint is saved after every batch, next run resume from the checkpoint.
+func (idx *Service) Reindex(ctx context.Context, o ReindexOptions) (p *ReindexProgress, err error) {
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.Checkpoint == "" {
+		o.Checkpoint = "reindex"
+	}
+	db := idx.DB.WithContext(ctx)
+	p = &ReindexProgress{}
+	if !o.Restart {
+		var cp models.Checkpoint
+		if err = db.Where(models.Checkpoint{Name: o.Checkpoint}).Limit(1).Find(&cp).Error; err != nil {
+			return nil, errors.Wrap(err, "load checkpoint")
+		}
+		p.Position = cp.Position
+	}
+	if err = db.Model(models.MetaFile{}).Where("id > ?", p.Position).Count(&p.Total).Error; err != nil {
+		return nil, errors.Wrap(err, "count meta file")
+	}
+	log.Info().Str("checkpoint", o.Checkpoint).Uint("position", p.Position).Int64("total", p.Total).Msg("reindex")
+
+	for {
+		var out []*models.MetaFile
+		err = db.Where("id > ?", p.Position).Order("id").Limit(o.BatchSize).Preload("Torrent").Find(&out).Error
+		if err != nil {
+			return p, errors.Wrap(err, "find meta file")
+		}
+		if len(out) == 0 {
+			break
+		}
+		for _, mf := range out {
+			if err = ctx.Err(); err != nil {
+				return
+			}
+			if err := idx.reindexMeta(ctx, mf); err != nil {
+				p.Failed++
+				log.Warn().Err(err).Str("hash", mf.TorrentHash).Str("content_hash", mf.ContentHash).Msg("reindex")
+			} else {
+				p.Done++
+			}
+			p.Position = mf.ID
+		}
+		err = db.Clauses(clause.OnConflict{
+			Columns:   models.Checkpoint{}.ConflictColumns(),
+			DoUpdates: clause.AssignmentColumns([]string{"position", "updated_at"}),
+		}).Create(&models.Checkpoint{Name: o.Checkpoint, Position: p.Position}).Error
+		if err != nil {
+			return p, errors.Wrap(err, "save checkpoint")
+		}
+		if o.OnProgress != nil {
+			o.OnProgress(p)
+		}
+	}
+	return
+}
+
+func (idx *Service) reindexMeta(ctx context.Context, mf *models.MetaFile) (err error) {
+	// rebuilt data is indexed as the meta file by content hash
+	data, _, err := LoadTorrentData(ctx, idx.DB, mf)
+	if err != nil {
+		return
+	}
+	t := &Torrent{
+		Data:     data,
+		FileInfo: &util.File{Path: mf.Filename, Length: mf.Size},
+	}
+	if err = t.LoadContext(ctx); err != nil {
+		return
+	}
+	_, err = idx.IndexTorrent(ctx, t, func(o *IndexTorrentOptions) {
+		o.Force = true
+		o.ContentHash = mf.ContentHash
+	})
+	return
+}
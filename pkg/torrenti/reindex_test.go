@@ -0,0 +1,54 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+)
+
+func TestReindex(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	tor := newTestTorrent(t, "test", 3)
+	_, err := svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+	_, err = svc.IndexTorrent(ctx, newTestTorrent(t, "other", 2))
+	assert.NoError(t, err)
+
+	hash := tor.Hash.String()
+	assert.NoError(t, svc.DB.Model(&models.Torrent{}).Where("hash = ?", hash).Updates(map[string]interface{}{"name": "broken", "file_count": 0}).Error)
+	assert.NoError(t, svc.DB.Where("torrent_hash = ?", hash).Delete(&models.TorrentFile{}).Error)
+	assert.NoError(t, svc.DB.Create(&models.TorrentFile{TorrentHash: hash, Path: "stale"}).Error)
+
+	var progress []uint
+	p, err := svc.Reindex(ctx, ReindexOptions{BatchSize: 1, OnProgress: func(p *ReindexProgress) {
+		progress = append(progress, p.Position)
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), p.Total)
+	assert.Equal(t, int64(2), p.Done)
+	assert.Len(t, progress, 2)
+
+	var tt models.Torrent
+	assert.NoError(t, svc.DB.Where("hash = ?", hash).Find(&tt).Error)
+	assert.Equal(t, "test", tt.Name)
+	assert.Equal(t, 3, tt.FileCount)
+	var paths []string
+	assert.NoError(t, svc.DB.Model(models.TorrentFile{}).Where("torrent_hash = ?", hash).Order("path").Pluck("path", &paths).Error)
+	assert.Equal(t, []string{"0.txt", "1.txt", "2.txt"}, paths)
+
+	var n int64
+	assert.NoError(t, svc.DB.Model(models.MetaFile{}).Count(&n).Error)
+	assert.Equal(t, int64(2), n)
+
+	// resume from checkpoint
+	p, err = svc.Reindex(ctx, ReindexOptions{})
+	assert.NoError(t, err)
+	assert.Zero(t, p.Total)
+	p, err = svc.Reindex(ctx, ReindexOptions{Restart: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), p.Done)
+}
@@ -1,6 +1,8 @@
 package torrenti
 
 import (
+	"context"
+
 	"github.com/wenerme/torrenti/pkg/search"
 	"github.com/wenerme/torrenti/pkg/torrenti/models"
 )
@@ -11,3 +13,19 @@ type TorrentSearchMatch struct {
 	HighlightFileName    string
 	HighlightTorrentName string
 }
+
+// SearchTorrentHashes return torrent hashes matched the query
+func SearchTorrentHashes(ctx context.Context, ss *search.Service, q string, limit int) ([]string, error) {
+	sr, err := ss.SearchTorrent(ctx, &search.SearchRequest{
+		QueryString: q,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(sr.Docs))
+	for _, v := range sr.Docs {
+		hashes = append(hashes, v.ID)
+	}
+	return hashes, nil
+}
@@ -0,0 +1,240 @@
+package torrenti
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"github.com/wenerme/torrenti/pkg/search"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"gorm.io/gorm"
+)
+
+// enqueueSearchTx record torrents to sync to search index
+func enqueueSearchTx(tx *gorm.DB, hashes []string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+	rows := lo.Map(hashes, func(h string, i int) *models.SearchOutbox {
+		return &models.SearchOutbox{TorrentHash: h}
+	})
+	return errors.Wrap(tx.CreateInBatches(rows, IndexFileBatchSize).Error, "save search outbox")
+}
+
+// EnqueueSearchAll queue all torrents to sync, used to rebuild outdated search index
+func (idx *Service) EnqueueSearchAll(ctx context.Context) (n int, err error) {
+	db := idx.DB.WithContext(ctx)
+	lastID := uint(0)
+	for {
+		var out []*models.Torrent
+		if err = db.Select("id", "hash").Where("id > ?", lastID).Order("id").Limit(IndexFileBatchSize).Find(&out).Error; err != nil {
+			return n, errors.Wrap(err, "find torrent")
+		}
+		if len(out) == 0 {
+			break
+		}
+		lastID = out[len(out)-1].ID
+		err = enqueueSearchTx(db, lo.Map(out, func(v *models.Torrent, i int) string {
+			return v.Hash
+		}))
+		if err != nil {
+			return
+		}
+		n += len(out)
+	}
+	idx.notifySearch()
+	return
+}
+
+// notifySearch wake up RunSearchSync
+func (idx *Service) notifySearch() {
+	select {
+	case idx.searchNotify <- struct{}{}:
+	default:
+	}
+}
+
+// NewTorrentDocument build search document from meta file with torrent
+func NewTorrentDocument(mf *models.MetaFile) *search.TorrentDocument {
+	return &search.TorrentDocument{
+		ID:              mf.TorrentHash,
+		MetaFileName:    mf.Filename,
+		TorrentFileName: mf.Torrent.Name,
+		Size:            mf.Torrent.TotalFileSize,
+		CreatedAt:       time.Unix(mf.CreationDate, 0),
+		Private:         mf.Torrent.Private,
+		Source:          mf.Torrent.Source,
+		HasWebSeed:      mf.Torrent.HasWebSeed,
+		FileCount:       mf.Torrent.FileCount,
+		IsDir:           mf.Torrent.IsDir,
+		Domain:          refererDomain(mf.Referer),
+	}
+}
+
+func refererDomain(referer *string) string {
+	if referer == nil {
+		return ""
+	}
+	u, err := url.Parse(*referer)
+	if err != nil {
+		return ""
+	}
+	return search.NormalizeDomain(u.Hostname())
+}
+
+// TorrentDocuments build search documents of meta files with file extensions and paths, meta file without torrent is skipped
+func TorrentDocuments(ctx context.Context, db *gorm.DB, mfs []*models.MetaFile) ([]*search.TorrentDocument, error) {
+	docs := make([]*search.TorrentDocument, 0, len(mfs))
+	for _, v := range mfs {
+		if v.Torrent != nil {
+			docs = append(docs, NewTorrentDocument(v))
+		}
+	}
+	if len(docs) == 0 {
+		return docs, nil
+	}
+	var exts []*models.TorrentFile
+	err := db.WithContext(ctx).Model(models.TorrentFile{}).Distinct("torrent_hash", "ext").
+		Where("torrent_hash in (?) and ext != ''", lo.Map(docs, func(d *search.TorrentDocument, i int) string {
+			return d.ID
+		})).
+		Order("ext").
+		Find(&exts).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find torrent file ext")
+	}
+	byHash := lo.GroupBy(exts, func(v *models.TorrentFile) string {
+		return v.TorrentHash
+	})
+	for _, d := range docs {
+		for _, v := range byHash[d.ID] {
+			d.Exts = append(d.Exts, v.Ext)
+		}
+	}
+	return docs, loadDocumentPaths(ctx, db, docs)
+}
+
+// loadDocumentPaths load paths of dir torrents, largest files are kept for huge torrent
+func loadDocumentPaths(ctx context.Context, db *gorm.DB, docs []*search.TorrentDocument) error {
+	db = db.WithContext(ctx)
+	var small []string
+	for _, d := range docs {
+		switch {
+		case !d.IsDir:
+			// path of single file torrent is the name
+		case d.FileCount <= search.MaxDocumentPaths:
+			small = append(small, d.ID)
+		default:
+			var paths []string
+			err := db.Model(models.TorrentFile{}).Where("torrent_hash = ?", d.ID).Order("size desc, path").Limit(search.MaxDocumentPaths).Pluck("path", &paths).Error
+			if err != nil {
+				return errors.Wrap(err, "find torrent file path")
+			}
+			d.Paths = paths
+		}
+	}
+	if len(small) == 0 {
+		return nil
+	}
+	byID := lo.KeyBy(docs, func(d *search.TorrentDocument) string {
+		return d.ID
+	})
+	var files []*models.TorrentFile
+	err := db.Select("torrent_hash", "path").Where("torrent_hash in (?)", small).Order("torrent_hash, path").Find(&files).Error
+	if err != nil {
+		return errors.Wrap(err, "find torrent file path")
+	}
+	for _, v := range files {
+		if d := byID[v.TorrentHash]; d != nil {
+			d.Paths = append(d.Paths, v.Path)
+		}
+	}
+	return nil
+}
+
+// TorrentDocumentScope select columns used by NewTorrentDocument
+func TorrentDocumentScope(db *gorm.DB) *gorm.DB {
+	return db.Select([]string{"id", "filename", "content_hash", "torrent_hash", "creation_date", "referer"}).
+		Preload("Torrent", func(db *gorm.DB) *gorm.DB {
+			return db.Select([]string{"name", "hash", "total_file_size", "file_count", "is_dir", "private", "source", "has_web_seed"})
+		})
+}
+
+// SyncSearch write pending torrents to search index, torrent without document is removed
+func (idx *Service) SyncSearch(ctx context.Context, ss *search.Service, batchSize int) (n int, err error) {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	db := idx.DB.WithContext(ctx)
+	for {
+		var rows []*models.SearchOutbox
+		if err = db.Order("id").Limit(batchSize).Find(&rows).Error; err != nil {
+			return n, errors.Wrap(err, "find search outbox")
+		}
+		if len(rows) == 0 {
+			return
+		}
+		hashes := lo.Uniq(lo.Map(rows, func(v *models.SearchOutbox, i int) string {
+			return v.TorrentHash
+		}))
+
+		var out []*models.MetaFile
+		err = db.Model(models.MetaFile{}).Scopes(TorrentDocumentScope).
+			Where("torrent_hash in (?)", hashes).Order("id").
+			Find(&out).Error
+		if err != nil {
+			return n, errors.Wrap(err, "find meta file")
+		}
+		all, err := TorrentDocuments(ctx, db, out)
+		if err != nil {
+			return n, err
+		}
+		// last meta file wins, same as full index
+		docs := lo.KeyBy(all, func(d *search.TorrentDocument) string {
+			return d.ID
+		})
+		deleted := lo.Filter(hashes, func(h string, i int) bool {
+			return docs[h] == nil
+		})
+		if err = ss.UpdateTorrent(ctx, lo.Values(docs), deleted); err != nil {
+			return n, errors.Wrap(err, "update search index")
+		}
+		ids := lo.Map(rows, func(v *models.SearchOutbox, i int) uint {
+			return v.ID
+		})
+		if err = db.Where("id in (?)", ids).Delete(&models.SearchOutbox{}).Error; err != nil {
+			return n, errors.Wrap(err, "delete search outbox")
+		}
+		n += len(hashes)
+		log.Debug().Int("indexed", len(docs)).Int("deleted", len(deleted)).Msg("sync search")
+	}
+}
+
+// RunSearchSync sync search index when torrent indexed or deleted, and every interval for changes of other process
+func (idx *Service) RunSearchSync(ctx context.Context, ss *search.Service, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if _, err := idx.SyncSearch(ctx, ss, 0); err != nil && ctx.Err() == nil {
+			log.Err(err).Msg("sync search")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		case <-idx.searchNotify:
+			// batch burst of indexing
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
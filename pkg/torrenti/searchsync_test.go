@@ -0,0 +1,89 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/search"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+)
+
+func TestSyncSearch(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	ss, err := search.NewService(search.NewServiceOptions{DataDir: t.TempDir(), Write: true})
+	assert.NoError(t, err)
+	defer ss.Close()
+
+	find := func(q string) []string {
+		hashes, err := SearchTorrentHashes(ctx, ss, q, 10)
+		assert.NoError(t, err)
+		return hashes
+	}
+
+	tor := newTestTorrent(t, "live", 1)
+	_, err = svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+	assert.Empty(t, find("live"))
+
+	n, err := svc.SyncSearch(ctx, ss, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{tor.Hash.String()}, find("live"))
+
+	var pending int64
+	assert.NoError(t, svc.DB.Model(models.SearchOutbox{}).Count(&pending).Error)
+	assert.Zero(t, pending)
+
+	// unchanged torrent is not queued again
+	_, err = svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DB.Model(models.SearchOutbox{}).Count(&pending).Error)
+	assert.Zero(t, pending)
+
+	_, err = svc.DeleteTorrent(ctx, &DeleteTorrentRequest{Hashes: []string{tor.Hash.String()}})
+	assert.NoError(t, err)
+	n, err = svc.SyncSearch(ctx, ss, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Empty(t, find("live"))
+
+	// largest files are indexed for huge torrent
+	small := newTestTorrent(t, "small", 2)
+	huge := newTestTorrent(t, "huge", search.MaxDocumentPaths+1)
+	for _, v := range []*Torrent{small, huge} {
+		_, err = svc.IndexTorrent(ctx, v)
+		assert.NoError(t, err)
+	}
+	_, err = svc.SyncSearch(ctx, ss, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{small.Hash.String()}, find("path:0"))
+	assert.Equal(t, []string{huge.Hash.String()}, find("path:1000"))
+
+	// rebuild outdated index
+	n, err = svc.EnqueueSearchAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = svc.SyncSearch(ctx, ss, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestSyncSearchDirPaths(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	ss, err := search.NewService(search.NewServiceOptions{DataDir: t.TempDir(), Write: true})
+	assert.NoError(t, err)
+	defer ss.Close()
+
+	// dir with one file
+	tor := newTestTorrent(t, "single", 1)
+	_, err = svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+	_, err = svc.SyncSearch(ctx, ss, 0)
+	assert.NoError(t, err)
+	hashes, err := SearchTorrentHashes(ctx, ss, "path:0", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{tor.Hash.String()}, hashes)
+}
@@ -2,14 +2,26 @@ package services
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
 	torrentiv12 "github.com/wenerme/torrenti/pkg/apis/media/torrenti/v1"
+	"github.com/wenerme/torrenti/pkg/search"
 	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
 	"github.com/wenerme/torrenti/pkg/torrenti/util/protou"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type TorrentIndexerServer struct {
-	Indexer *torrenti.Service
+	Indexer    *torrenti.Service
+	Search     *search.Service // optional, used to delete by query and remove deleted documents
+	VerifyDirs []string        // allowed dirs for verify, verify is disabled if empty
+	CreateDirs []string        // allowed dirs for create, create is disabled if empty
 	torrentiv12.UnimplementedTorrentIndexServiceServer
 }
 
@@ -25,6 +37,7 @@ func (i *TorrentIndexerServer) Stat(ctx context.Context, req *torrentiv12.StatRe
 			TorrentCount:         stat.TorrentCount,
 			TorrentFileCount:     stat.TorrentFileCount,
 			TorrentFileTotalSize: stat.TorrentFileTotalSize,
+			TombstoneCount:       stat.TombstoneCount,
 		},
 	}
 	return
@@ -35,6 +48,160 @@ func (i *TorrentIndexerServer) IndexTorrent(ctx context.Context, request *torren
 	_, err = i.Indexer.IndexTorrent(ctx, &torrenti.Torrent{
 		File: file,
 		H:    request.GetHash(),
+	}, func(o *torrenti.IndexTorrentOptions) {
+		o.Force = request.GetForce()
 	})
 	return nil, err
 }
+
+func (i *TorrentIndexerServer) DeleteTorrent(ctx context.Context, req *torrentiv12.DeleteTorrentRequest) (resp *torrentiv12.DeleteTorrentResponse, err error) {
+	dr := &torrenti.DeleteTorrentRequest{
+		Hashes:        req.GetHashes(),
+		ContentHashes: req.GetContentHashes(),
+		Reason:        req.GetReason(),
+	}
+	if req.GetQuery() != "" {
+		if i.Search == nil {
+			return nil, status.Error(codes.FailedPrecondition, "search is not available")
+		}
+		sr, err := i.Search.SearchTorrent(ctx, &search.SearchRequest{QueryString: req.GetQuery(), Limit: 1000})
+		if err != nil {
+			return nil, err
+		}
+		dr.Hashes = append(dr.Hashes, lo.Map(sr.Docs, func(t *search.DocumentMatch, i int) string {
+			return t.ID
+		})...)
+	}
+	if len(dr.Hashes) == 0 && len(dr.ContentHashes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "nothing to delete")
+	}
+	r, err := i.Indexer.DeleteTorrent(ctx, dr)
+	if err != nil {
+		return
+	}
+	if i.Search != nil && len(r.Hashes) > 0 {
+		// removed by search sync if index is read only now
+		if err := i.Search.DeleteTorrent(ctx, r.Hashes); err != nil {
+			log.Warn().Err(err).Msg("delete search document")
+		}
+	}
+	resp = &torrentiv12.DeleteTorrentResponse{
+		Hashes:       r.Hashes,
+		MetaCount:    r.MetaCount,
+		TorrentCount: r.TorrentCount,
+	}
+	return
+}
+
+func (i *TorrentIndexerServer) CreateTorrent(ctx context.Context, req *torrentiv12.CreateTorrentRequest) (resp *torrentiv12.CreateTorrentResponse, err error) {
+	p, err := localPath(req.GetPath(), i.CreateDirs, "create")
+	if err != nil {
+		return
+	}
+	// p is resolved, name by requested path
+	name := req.GetName()
+	if name == "" {
+		abs, _ := filepath.Abs(req.GetPath())
+		name = filepath.Base(abs)
+	}
+	data, err := torrenti.CreateTorrent(ctx, torrenti.CreateTorrentOptions{
+		Path:         p,
+		Name:         name,
+		Version:      req.GetVersion(),
+		PieceLength:  req.GetPieceLength(),
+		Trackers:     torrenti.ParseTrackerTiers(req.GetTrackers()),
+		WebSeeds:     req.GetWebSeeds(),
+		Private:      req.GetPrivate(),
+		Source:       req.GetSource(),
+		Comment:      req.GetComment(),
+		CreatedBy:    "torrenti",
+		CreationDate: time.Now(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	t := &torrenti.Torrent{Data: data, FileInfo: &util.File{Path: name + ".torrent", Length: int64(len(data))}}
+	if err = t.LoadContext(ctx); err != nil {
+		return
+	}
+	if req.GetIndex() {
+		if _, err = i.Indexer.IndexTorrent(ctx, t); err != nil {
+			return
+		}
+	}
+	resp = &torrentiv12.CreateTorrentResponse{
+		Data: data,
+		Hash: t.Hash.String(),
+	}
+	if !t.HashV2.IsZero() {
+		resp.HashV2 = t.HashV2.String()
+	}
+	return
+}
+
+func (i *TorrentIndexerServer) VerifyTorrent(ctx context.Context, req *torrentiv12.VerifyTorrentRequest) (resp *torrentiv12.VerifyTorrentResponse, err error) {
+	p, err := localPath(req.GetPath(), i.VerifyDirs, "verify")
+	if err != nil {
+		return
+	}
+	r, err := i.Indexer.VerifyTorrent(ctx, req.GetHash(), p)
+	if err != nil {
+		return
+	}
+	resp = &torrentiv12.VerifyTorrentResponse{
+		Pieces:     int32(r.Pieces),
+		GoodPieces: int32(r.GoodPieces),
+		Missing:    r.Missing,
+		Complete:   r.Complete(),
+	}
+	for _, v := range r.BadPieces {
+		resp.BadPieces = append(resp.BadPieces, int32(v))
+	}
+	for _, v := range r.Files {
+		resp.Files = append(resp.Files, &torrentiv12.VerifyFile{
+			Path:       v.Path,
+			Size:       v.Size,
+			LocalSize:  v.LocalSize,
+			Missing:    v.Missing,
+			Pieces:     int32(v.Pieces),
+			GoodPieces: int32(v.GoodPieces),
+			RootMatch:  v.RootMatch,
+		})
+	}
+	return
+}
+
+// localPath check path is under allowed dirs of op, symlinks are resolved before check
+func localPath(path string, dirs []string, op string) (string, error) {
+	if len(dirs) == 0 {
+		return "", status.Error(codes.PermissionDenied, op+" is disabled")
+	}
+	p, err := filepath.Abs(path)
+	if err != nil || path == "" {
+		return "", status.Error(codes.InvalidArgument, "invalid path")
+	}
+	if p, err = filepath.EvalSymlinks(p); err != nil {
+		return "", status.Error(codes.NotFound, "path not found")
+	}
+	if !underDirs(p, dirs) {
+		return "", status.Error(codes.PermissionDenied, "path not allowed")
+	}
+	return p, nil
+}
+
+// underDirs check resolved path p is under dirs, dirs are resolved too
+func underDirs(p string, dirs []string) bool {
+	for _, dir := range dirs {
+		dir, err := filepath.Abs(dir)
+		if err == nil {
+			dir, err = filepath.EvalSymlinks(dir)
+		}
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, p); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
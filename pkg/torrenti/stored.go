@@ -0,0 +1,48 @@
+package torrenti
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+// FindMetaFiles find meta files with torrent by torrent hash, v2 hash or content hash
+func (idx *Service) FindMetaFiles(ctx context.Context, hash string) (out []*models.MetaFile, err error) {
+	hashes := []string{hash}
+	if h, err := magnet.ParseHash(hash); err == nil {
+		hashes = append(hashes, h.String())
+	}
+	db := idx.DB.WithContext(ctx)
+	err = db.Preload("Torrent").
+		Where("content_hash in (?) or torrent_hash in (?) or torrent_hash in (?)", hashes, hashes,
+			db.Model(models.Torrent{}).Select("hash").Where("hash_v2 in (?)", hashes)).
+		Order("id").
+		Find(&out).Error
+	err = errors.Wrap(err, "find meta files")
+	return
+}
+
+// StoredTorrent load torrent from stored meta file
+func (idx *Service) StoredTorrent(ctx context.Context, mf *models.MetaFile) (t *Torrent, err error) {
+	data, _, err := LoadTorrentData(ctx, idx.DB, mf)
+	if err != nil {
+		return
+	}
+	t = &Torrent{
+		Data: data,
+		FileInfo: &util.File{
+			Path:   mf.Filename,
+			Length: int64(len(data)),
+		},
+	}
+	if err = t.Load(); err != nil {
+		return
+	}
+	if mf.Referer != nil {
+		t.URL = *mf.Referer
+	}
+	return
+}
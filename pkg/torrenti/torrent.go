@@ -2,6 +2,8 @@ package torrenti
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/fs"
@@ -14,7 +16,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/metadata"
 	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
 	"github.com/xgfone/bt/metainfo"
 )
 
@@ -40,19 +44,26 @@ func ParseTorrent(v string) (o *Torrent, err error) {
 }
 
 type Torrent struct {
-	Magnet   magnet.Magnet
-	Hash     magnet.Hash
-	Path     string
-	FileInfo fs.FileInfo
-	Data     []byte
-	Meta     *metainfo.MetaInfo
-	URL      string
-	Response *http.Response
-	File     *util.File
-	H        string
+	Magnet      magnet.Magnet
+	Hash        magnet.Hash // v1 sha1 info hash, truncated v2 hash for pure v2 torrent
+	HashV2      magnet.Hash // v2 sha256 info hash
+	Path        string
+	FileInfo    fs.FileInfo
+	Data        []byte
+	Meta        *metainfo.MetaInfo
+	Info        *Info
+	PieceLayers []byte // raw bencoded piece layers of v2 torrent
+	URL         string
+	Response    *http.Response
+	File        *util.File
+	H           string
 }
 
 func (t *Torrent) Load() (err error) {
+	return t.LoadContext(context.Background())
+}
+
+func (t *Torrent) LoadContext(ctx context.Context) (err error) {
 	switch {
 	case t.URL != "":
 		if t.Response == nil && t.Data == nil {
@@ -89,11 +100,10 @@ func (t *Torrent) Load() (err error) {
 			return
 		}
 
-	case !t.Magnet.Hash.IsZero():
-		// dht.Config{}
-		err = errors.New("TODO: load magnet")
 	case t.Data != nil && t.FileInfo != nil:
 		// data ready
+	case !t.Magnet.Hash.IsZero():
+		err = t.loadMagnet(ctx)
 	default:
 		err = errors.New("invalid torrent info")
 	}
@@ -103,14 +113,84 @@ func (t *Torrent) Load() (err error) {
 	return
 }
 
+func (t *Torrent) loadMagnet(ctx context.Context) (err error) {
+	fetcher := metadata.FetcherContextKey.Get(ctx)
+	if fetcher == nil {
+		fetcher = metadata.DefaultFetcher
+	}
+	m := t.Magnet
+	info, err := fetcher.Fetch(ctx, m)
+	if err != nil {
+		return
+	}
+
+	d := map[string]interface{}{
+		"info": bencode.RawMessage(info),
+	}
+	if len(m.Trackers) > 0 {
+		d["announce"] = m.Trackers[0]
+		// tier is unknown, one tracker per tier
+		tiers := make([][]string, 0, len(m.Trackers))
+		for _, v := range m.Trackers {
+			tiers = append(tiers, []string{v})
+		}
+		d["announce-list"] = tiers
+	}
+	t.Data, err = bencode.EncodeBytes(d)
+	if err != nil {
+		return errors.Wrap(err, "encode torrent")
+	}
+
+	name := m.DisplayName
+	if name == "" {
+		name = m.Hash.HexHash()
+	}
+	t.FileInfo = &util.File{
+		Path:   name + ".torrent",
+		Length: int64(len(t.Data)),
+		Data:   t.Data,
+	}
+	return
+}
+
 func (t *Torrent) loadData() (err error) {
 	var meta metainfo.MetaInfo
 
 	meta, err = metainfo.Load(bytes.NewReader(t.Data))
-	err = errors.Wrap(err, "load metainfo")
+	if err = errors.Wrap(err, "load metainfo"); err != nil {
+		return
+	}
 	t.Meta = &meta
-	t.Hash = magnet.Hash{
-		Digest: meta.InfoHash().Bytes(),
+
+	var info Info
+	info, err = ParseInfo(meta.InfoBytes)
+	if err != nil {
+		return
+	}
+	t.Info = &info
+
+	if info.IsV2() {
+		sum := sha256.Sum256(meta.InfoBytes)
+		t.HashV2 = magnet.Hash{
+			Name:   "sha2-256",
+			Digest: sum[:],
+		}
+		var raw struct {
+			PieceLayers bencode.RawMessage `bencode:"piece layers,omitempty"`
+		}
+		if err = bencode.DecodeBytes(t.Data, &raw); err != nil {
+			return errors.Wrap(err, "decode piece layers")
+		}
+		t.PieceLayers = raw.PieceLayers
+	}
+	if info.IsV1() {
+		t.Hash = magnet.Hash{
+			Digest: meta.InfoHash().Bytes(),
+		}
+	} else {
+		t.Hash = magnet.Hash{
+			Digest: t.HashV2.Digest[:20],
+		}
 	}
 	t.Magnet = magnet.Magnet{
 		Hash: t.Hash,
@@ -0,0 +1,83 @@
+package torrenti
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xgfone/bt/metainfo"
+)
+
+// TrackerURL is a normalized tracker announce url
+type TrackerURL struct {
+	URL      string
+	Protocol string // http, https, udp, ws, wss
+	Tier     int    // BEP 12 tier, announce only is tier 0
+}
+
+// NormalizeTracker lower case scheme and host, drop default port and fragment
+func NormalizeTracker(s string) (out TrackerURL, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		err = errors.New("empty tracker")
+		return
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		err = errors.Wrap(err, "parse tracker")
+		return
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	switch u.Scheme {
+	case "http", "https", "udp", "ws", "wss":
+	default:
+		err = errors.Errorf("unsupported tracker protocol: %q", u.Scheme)
+		return
+	}
+	if u.Host == "" {
+		err = errors.Errorf("invalid tracker: %q", s)
+		return
+	}
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	switch {
+	case port == "80" && (u.Scheme == "http" || u.Scheme == "ws"),
+		port == "443" && (u.Scheme == "https" || u.Scheme == "wss"):
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+
+	out.URL = u.String()
+	out.Protocol = u.Scheme
+	return
+}
+
+// Trackers collect normalized trackers from announce and announce-list, invalid trackers are skipped
+func Trackers(mi *metainfo.MetaInfo) (out []TrackerURL) {
+	seen := map[string]bool{}
+	add := func(s string, tier int) {
+		v, err := NormalizeTracker(s)
+		if err != nil || seen[v.URL] {
+			return
+		}
+		seen[v.URL] = true
+		v.Tier = tier
+		out = append(out, v)
+	}
+	for i, tier := range mi.AnnounceList {
+		for _, v := range tier {
+			add(v, i)
+		}
+	}
+	add(mi.Announce, 0)
+	return
+}
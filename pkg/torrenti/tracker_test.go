@@ -0,0 +1,39 @@
+package torrenti
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xgfone/bt/metainfo"
+)
+
+func TestTrackers(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{in: "udp://Tracker.Example.com:80/announce", out: "udp://tracker.example.com:80/announce"},
+		{in: " HTTP://tracker.example.com:80/announce#x ", out: "http://tracker.example.com/announce"},
+		{in: "https://user@tracker.example.com:443/a?passkey=1", out: "https://tracker.example.com/a?passkey=1"},
+		{in: "udp://[2001:DB8::1]:6969", out: "udp://[2001:db8::1]:6969"},
+		{in: "dht://abc"},
+		{in: "tracker.example.com"},
+	} {
+		v, err := NormalizeTracker(test.in)
+		if test.out == "" {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.out, v.URL)
+	}
+
+	assert.Equal(t, []TrackerURL{
+		{URL: "udp://a:1", Protocol: "udp", Tier: 0},
+		{URL: "udp://b:1", Protocol: "udp", Tier: 1},
+		{URL: "http://c/announce", Protocol: "http", Tier: 0},
+	}, Trackers(&metainfo.MetaInfo{
+		Announce:     "http://c:80/announce",
+		AnnounceList: metainfo.AnnounceList{{"udp://a:1", "UDP://A:1"}, {"udp://b:1", "bad"}},
+	}))
+}
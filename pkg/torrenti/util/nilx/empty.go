@@ -34,3 +34,10 @@ func ZeroToNil(v int) *int {
 	}
 	return &v
 }
+
+func FalseToNil(v bool) *bool {
+	if !v {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ParseTime parse RFC3339 time or date in local time
+func ParseTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid time: %q", s)
+}
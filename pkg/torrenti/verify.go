@@ -0,0 +1,398 @@
+package torrenti
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wenerme/torrenti/pkg/magnet"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/xgfone/bt/bencode"
+)
+
+// VerifyFile is the verify result of one file, padding files are not included
+type VerifyFile struct {
+	Path       string `json:"path"`
+	Size       int64  `json:"size"`
+	LocalSize  int64  `json:"local_size"`
+	Missing    bool   `json:"missing,omitempty"`
+	Pieces     int    `json:"pieces"` // pieces overlap with the file
+	GoodPieces int    `json:"good_pieces"`
+	RootMatch  *bool  `json:"root_match,omitempty"` // v2 pieces root matched, nil if not checked
+}
+
+func (f *VerifyFile) Complete() float64 {
+	if f.Pieces == 0 {
+		if f.Missing {
+			return 0
+		}
+		return 1
+	}
+	return float64(f.GoodPieces) / float64(f.Pieces)
+}
+
+type VerifyResult struct {
+	Pieces     int           `json:"pieces"` // v1 pieces, or v2 pieces of all files for v2 only torrent
+	GoodPieces int           `json:"good_pieces"`
+	BadPieces  []int         `json:"bad_pieces,omitempty"` // index of bad v1 pieces
+	Files      []*VerifyFile `json:"files"`
+	Missing    []string      `json:"missing,omitempty"`
+}
+
+func (r *VerifyResult) Complete() float64 {
+	if r.Pieces == 0 {
+		if len(r.Missing) > 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(r.GoodPieces) / float64(r.Pieces)
+}
+
+// VerifyTorrent verify local data against stored torrent
+func (idx *Service) VerifyTorrent(ctx context.Context, hash string, path string) (*VerifyResult, error) {
+	h, err := magnet.ParseHash(hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hash")
+	}
+	var t models.Torrent
+	err = idx.DB.WithContext(ctx).Where("hash = ? or hash_v2 = ?", h.String(), h.String()).Limit(1).Find(&t).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find torrent")
+	}
+	if t.ID == 0 {
+		return nil, errors.Errorf("torrent not found: %s", hash)
+	}
+	info, err := ParseInfo(t.InfoBytes)
+	if err != nil {
+		return nil, err
+	}
+	return VerifyData(ctx, &info, t.PieceLayers, path)
+}
+
+// VerifyData hash local data of torrent, path is the torrent content or the dir contains it
+//
+// v1 pieces are checked for v1 and hybrid torrent, v2 pieces roots are checked for v2 and hybrid torrent.
+func VerifyData(ctx context.Context, info *Info, pieceLayers []byte, path string) (r *VerifyResult, err error) {
+	if info.PieceLength <= 0 {
+		return nil, errors.New("invalid piece length")
+	}
+	files, err := info.Files()
+	if err != nil {
+		return
+	}
+	root := verifyRoot(info, path)
+	locals := make([]string, len(files))
+	r = &VerifyResult{}
+	for i, f := range files {
+		locals[i] = root
+		if info.IsDir() {
+			locals[i] = verifyLocal(root, f.Paths)
+		}
+		if f.IsPadding() {
+			continue
+		}
+		vf := &VerifyFile{Path: f.Path(), Size: f.Length}
+		if !info.IsDir() {
+			vf.Path = info.Name
+		}
+		// unsafe path is never read, treat as missing
+		if fi, err := os.Stat(locals[i]); locals[i] == "" || err != nil || fi.IsDir() {
+			vf.Missing = true
+			r.Missing = append(r.Missing, vf.Path)
+		} else {
+			vf.LocalSize = fi.Size()
+		}
+		r.Files = append(r.Files, vf)
+	}
+
+	if info.IsV1() {
+		if err = verifyV1(ctx, info, files, locals, r); err != nil {
+			return
+		}
+	}
+	if info.IsV2() {
+		var layers map[string]string
+		if len(pieceLayers) > 0 {
+			if err = bencode.DecodeBytes(pieceLayers, &layers); err != nil {
+				return nil, errors.Wrap(err, "decode piece layers")
+			}
+		}
+		if err = verifyV2(ctx, info, files, locals, layers, r); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// verifyRoot find local root of torrent content, unsafe name is not joined
+func verifyRoot(info *Info, path string) string {
+	p := verifyLocal(path, []string{info.Name})
+	if fi, err := os.Stat(p); p != "" && err == nil && fi.IsDir() == info.IsDir() {
+		return p
+	}
+	return path
+}
+
+// verifyLocal join paths of torrent file to root, empty if any component is unsafe or result escape the root
+func verifyLocal(root string, paths []string) string {
+	for _, c := range paths {
+		if unsafePathComponent(c) != "" {
+			return ""
+		}
+	}
+	p := filepath.Clean(filepath.Join(append([]string{root}, paths...)...))
+	if rel, err := filepath.Rel(filepath.Clean(root), p); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return p
+}
+
+func verifyV1(ctx context.Context, info *Info, files []InfoFile, locals []string, r *VerifyResult) error {
+	var readers []io.Reader
+	var missing []bool
+	var ends []int64 // end offset of files
+	var off int64
+	var vfs []*VerifyFile
+	n := 0
+	for i, f := range files {
+		var vf *VerifyFile
+		if !f.IsPadding() {
+			vf = r.Files[n]
+			n++
+		}
+		switch {
+		case f.IsPadding():
+			readers = append(readers, io.LimitReader(zeroReader{}, f.Length))
+		case vf.Missing:
+			readers = append(readers, io.LimitReader(zeroReader{}, f.Length))
+		default:
+			readers = append(readers, &lazyFileReader{path: locals[i], size: f.Length})
+		}
+		missing = append(missing, vf != nil && vf.Missing)
+		vfs = append(vfs, vf)
+		off += f.Length
+		ends = append(ends, off)
+	}
+
+	rd := io.MultiReader(readers...)
+	buf := make([]byte, info.PieceLength)
+	total := len(info.Pieces)
+	r.Pieces = total
+	fi := 0
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		start := int64(i) * info.PieceLength
+		end := start + info.PieceLength
+		if end > off {
+			end = off
+		}
+		if start >= end {
+			r.BadPieces = append(r.BadPieces, i)
+			continue
+		}
+		b := buf[:end-start]
+		if _, err := io.ReadFull(rd, b); err != nil {
+			return errors.Wrapf(err, "read piece %v", i)
+		}
+
+		// files overlap with the piece
+		for fi < len(ends) && ends[fi] <= start && !(ends[fi] == start && files[fi].Length == 0) {
+			fi++
+		}
+		first := fi
+		skip := false
+		for j := first; j < len(ends); j++ {
+			if j > first && ends[j-1] >= end {
+				break
+			}
+			if missing[j] {
+				skip = true
+			}
+		}
+		good := !skip && bytes.Equal(sha1Sum(b), info.Pieces[i][:])
+		if good {
+			r.GoodPieces++
+		} else {
+			r.BadPieces = append(r.BadPieces, i)
+		}
+		for j := first; j < len(ends); j++ {
+			if j > first && ends[j-1] >= end {
+				break
+			}
+			if vfs[j] == nil || files[j].Length == 0 {
+				continue
+			}
+			vfs[j].Pieces++
+			if good {
+				vfs[j].GoodPieces++
+			}
+		}
+	}
+	return nil
+}
+
+func verifyV2(ctx context.Context, info *Info, files []InfoFile, locals []string, layers map[string]string, r *VerifyResult) error {
+	n := 0
+	for i, f := range files {
+		if f.IsPadding() {
+			continue
+		}
+		vf := r.Files[n]
+		n++
+		if f.Length == 0 {
+			continue
+		}
+		pieces := int((f.Length + info.PieceLength - 1) / info.PieceLength)
+		if !info.IsV1() {
+			vf.Pieces = pieces
+			r.Pieces += pieces
+		}
+		match := false
+		vf.RootMatch = &match
+		if vf.Missing {
+			continue
+		}
+
+		fr, err := os.Open(locals[i])
+		if err != nil {
+			return err
+		}
+		root, layer, err := merkleFile(ctx, io.LimitReader(fr, f.Length), info.PieceLength)
+		_ = fr.Close()
+		if err != nil {
+			return errors.Wrapf(err, "hash %s", vf.Path)
+		}
+		match = vf.LocalSize == f.Length && bytes.Equal(root, f.PiecesRoot)
+		if info.IsV1() {
+			continue
+		}
+
+		good := 0
+		switch {
+		case match:
+			good = pieces
+		case vf.LocalSize == f.Length && f.Length > info.PieceLength:
+			expected := layers[string(f.PiecesRoot)]
+			for j, v := range layer {
+				if (j+1)*32 <= len(expected) && expected[j*32:(j+1)*32] == string(v) {
+					good++
+				}
+			}
+		}
+		vf.GoodPieces = good
+		r.GoodPieces += good
+	}
+	return nil
+}
+
+// merkleFile compute BEP 52 pieces root and piece layer of file
+func merkleFile(ctx context.Context, rd io.Reader, pieceLength int64) (root []byte, layer [][]byte, err error) {
+	const blockSize = 16 * 1024
+	var leaves [][]byte
+	buf := make([]byte, blockSize)
+	for {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		var n int
+		n, err = io.ReadFull(rd, buf)
+		if n > 0 {
+			sum := sha256.Sum256(buf[:n])
+			leaves = append(leaves, sum[:])
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return
+		}
+	}
+	if len(leaves) == 0 {
+		return
+	}
+	perPiece := int(pieceLength / blockSize)
+	if len(leaves) > perPiece {
+		for i := 0; i < len(leaves); i += perPiece {
+			end := i + perPiece
+			if end > len(leaves) {
+				end = len(leaves)
+			}
+			layer = append(layer, merkleRoot(leaves[i:end], perPiece))
+		}
+	}
+	n := 1
+	for n < len(leaves) {
+		n <<= 1
+	}
+	root = merkleRoot(leaves, n)
+	return
+}
+
+// merkleRoot compute root of hashes padded with zero to n leaves, n is power of two
+func merkleRoot(hashes [][]byte, n int) []byte {
+	layer := make([][]byte, n)
+	copy(layer, hashes)
+	zero := make([]byte, 32)
+	for i := len(hashes); i < n; i++ {
+		layer[i] = zero
+	}
+	for len(layer) > 1 {
+		next := make([][]byte, len(layer)/2)
+		for i := range next {
+			h := sha256.New()
+			h.Write(layer[2*i])
+			h.Write(layer[2*i+1])
+			next[i] = h.Sum(nil)
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+func sha1Sum(b []byte) []byte {
+	sum := sha1.Sum(b)
+	return sum[:]
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// lazyFileReader open file when read and close at the end, short file is padded with zero
+type lazyFileReader struct {
+	path string
+	size int64
+	r    io.Reader
+	f    *os.File
+}
+
+func (l *lazyFileReader) Read(p []byte) (n int, err error) {
+	if l.r == nil {
+		if l.f, err = os.Open(l.path); err != nil {
+			return
+		}
+		l.r = io.MultiReader(io.LimitReader(l.f, l.size), zeroReader{})
+		l.r = io.LimitReader(l.r, l.size)
+	}
+	n, err = l.r.Read(p)
+	if err == io.EOF && l.f != nil {
+		_ = l.f.Close()
+		l.f = nil
+	}
+	return
+}
@@ -0,0 +1,105 @@
+package torrenti
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xgfone/bt/bencode"
+)
+
+func TestVerifyData(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a := bytes.Repeat([]byte("a"), 20000)
+	b := bytes.Repeat([]byte("b"), 15000)
+	all := append(append([]byte{}, a...), b...)
+	var pieces []byte
+	for i := 0; i < len(all); i += 16384 {
+		end := i + 16384
+		if end > len(all) {
+			end = len(all)
+		}
+		sum := sha1.Sum(all[i:end])
+		pieces = append(pieces, sum[:]...)
+	}
+	root, _, err := merkleFile(ctx, bytes.NewReader(a), 16384)
+	assert.NoError(t, err)
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"name":         "test",
+		"piece length": 16384,
+		"pieces":       string(pieces),
+		"files": []interface{}{
+			map[string]interface{}{"length": len(a), "path": []string{"a.txt"}},
+			map[string]interface{}{"length": len(b), "path": []string{"sub", "b.txt"}},
+		},
+	})
+	assert.NoError(t, err)
+	info, err := ParseInfo(data)
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "test", "sub"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test", "a.txt"), a, 0o644))
+
+	r, err := VerifyData(ctx, &info, nil, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, r.Pieces)
+	assert.Equal(t, 1, r.GoodPieces)
+	assert.Equal(t, []int{1, 2}, r.BadPieces)
+	assert.Equal(t, []string{"sub/b.txt"}, r.Missing)
+	assert.Equal(t, 2, r.Files[0].Pieces)
+	assert.Equal(t, 1, r.Files[0].GoodPieces)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test", "sub", "b.txt"), b, 0o644))
+	r, err = VerifyData(ctx, &info, nil, filepath.Join(dir, "test"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, r.Complete())
+	assert.Empty(t, r.Missing)
+
+	// v2 single file
+	data, err = bencode.EncodeBytes(map[string]interface{}{
+		"name":         "a.txt",
+		"piece length": 16384,
+		"meta version": 2,
+		"file tree": map[string]interface{}{
+			"a.txt": map[string]interface{}{"": map[string]interface{}{"length": len(a), "pieces root": string(root)}},
+		},
+	})
+	assert.NoError(t, err)
+	info, err = ParseInfo(data)
+	assert.NoError(t, err)
+	r, err = VerifyData(ctx, &info, nil, filepath.Join(dir, "test"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, r.Pieces)
+	assert.Equal(t, 1.0, r.Complete())
+	assert.True(t, *r.Files[0].RootMatch)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test", "a.txt"), []byte(strings.Repeat("x", len(a))), 0o644))
+	r, err = VerifyData(ctx, &info, nil, filepath.Join(dir, "test", "a.txt"))
+	assert.NoError(t, err)
+	assert.Zero(t, r.GoodPieces)
+	assert.False(t, *r.Files[0].RootMatch)
+
+	// path escape the root is not read
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), b, 0o644))
+	data, err = bencode.EncodeBytes(map[string]interface{}{
+		"name":         "test",
+		"piece length": 16384,
+		"pieces":       string(pieces[20:]),
+		"files": []interface{}{
+			map[string]interface{}{"length": len(b), "path": []string{"..", "b.txt"}},
+		},
+	})
+	assert.NoError(t, err)
+	info, err = ParseInfo(data)
+	assert.NoError(t, err)
+	r, err = VerifyData(ctx, &info, nil, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"../b.txt"}, r.Missing)
+	assert.Zero(t, r.GoodPieces)
+}
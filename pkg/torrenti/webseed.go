@@ -0,0 +1,118 @@
+package torrenti
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/xgfone/bt/bencode"
+	"github.com/xgfone/bt/metainfo"
+	"gorm.io/gorm"
+)
+
+const (
+	WebSeedTypeURLList   = "url-list"  // BEP 19, GetRight style
+	WebSeedTypeHTTPSeeds = "httpseeds" // BEP 17, Hoffman style
+)
+
+// WebSeedURL is a http web seed of torrent
+type WebSeedURL struct {
+	URL  string
+	Type string
+}
+
+// WebSeeds return http web seeds of loaded torrent, url-list first
+func WebSeeds(t *Torrent) (out []WebSeedURL) {
+	seen := map[WebSeedURL]bool{}
+	add := func(typ string, list []string) {
+		for _, v := range list {
+			v = strings.TrimSpace(v)
+			u, err := url.Parse(v)
+			if err != nil || u.Host == "" {
+				continue
+			}
+			if s := strings.ToLower(u.Scheme); s != "http" && s != "https" {
+				continue
+			}
+			ws := WebSeedURL{URL: v, Type: typ}
+			if !seen[ws] {
+				seen[ws] = true
+				out = append(out, ws)
+			}
+		}
+	}
+	if t.Meta != nil {
+		add(WebSeedTypeURLList, t.Meta.URLList)
+	}
+	add(WebSeedTypeHTTPSeeds, httpSeeds(t.Data))
+	return
+}
+
+// httpSeeds decode BEP 17 httpseeds, single string is accepted
+func httpSeeds(data []byte) []string {
+	var raw struct {
+		HTTPSeeds bencode.RawMessage `bencode:"httpseeds,omitempty"`
+	}
+	if len(data) == 0 || bencode.DecodeBytes(data, &raw) != nil || len(raw.HTTPSeeds) == 0 {
+		return nil
+	}
+	var list []string
+	if bencode.DecodeBytes(raw.HTTPSeeds, &list) == nil {
+		return list
+	}
+	var s string
+	if bencode.DecodeBytes(raw.HTTPSeeds, &s) == nil && s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// Nodes return unique BEP 5 dht bootstrap nodes
+func Nodes(mi *metainfo.MetaInfo) (out []metainfo.HostAddress) {
+	seen := map[string]bool{}
+	for _, v := range mi.Nodes {
+		v.Host = strings.TrimSpace(v.Host)
+		if v.Host == "" || v.Port == 0 || seen[v.String()] {
+			continue
+		}
+		seen[v.String()] = true
+		out = append(out, v)
+	}
+	return
+}
+
+// LoadWebSeeds load stored web seeds by torrent hash
+func LoadWebSeeds(ctx context.Context, db *gorm.DB, hashes []string) (out map[string][]WebSeedURL, err error) {
+	out = map[string][]WebSeedURL{}
+	if len(hashes) == 0 {
+		return
+	}
+	var list []models.TorrentWebSeed
+	err = db.WithContext(ctx).Select("torrent_hash", "url", "type").Where("torrent_hash in (?)", hashes).Order("id").Find(&list).Error
+	if err = errors.Wrap(err, "find torrent web seeds"); err != nil {
+		return
+	}
+	for _, v := range list {
+		out[v.TorrentHash] = append(out[v.TorrentHash], WebSeedURL{URL: v.URL, Type: v.Type})
+	}
+	return
+}
+
+// LoadNodes load stored dht nodes by torrent hash
+func LoadNodes(ctx context.Context, db *gorm.DB, hashes []string) (out map[string][]metainfo.HostAddress, err error) {
+	out = map[string][]metainfo.HostAddress{}
+	if len(hashes) == 0 {
+		return
+	}
+	var list []models.TorrentNode
+	err = db.WithContext(ctx).Select("torrent_hash", "host", "port").Where("torrent_hash in (?)", hashes).Order("id").Find(&list).Error
+	if err = errors.Wrap(err, "find torrent nodes"); err != nil {
+		return
+	}
+	for _, v := range list {
+		out[v.TorrentHash] = append(out[v.TorrentHash], metainfo.NewHostAddress(v.Host, uint16(v.Port)))
+	}
+	return
+}
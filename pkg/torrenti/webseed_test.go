@@ -0,0 +1,74 @@
+package torrenti
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/torrenti/pkg/torrenti/models"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+	"github.com/xgfone/bt/bencode"
+)
+
+func TestIndexExtendedInfo(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	data, err := bencode.EncodeBytes(map[string]interface{}{
+		"url-list":  []string{"http://a/", "ftp://b/", "http://a/"},
+		"httpseeds": "http://c/seed",
+		"nodes":     []interface{}{[]interface{}{"router.example.com", 6881}, []interface{}{"1.2.3.4", 0}},
+		"info": map[string]interface{}{
+			"name":         "test",
+			"piece length": 16384,
+			"pieces":       string(make([]byte, 20)),
+			"length":       10,
+			"private":      1,
+			"source":       "SRC",
+		},
+	})
+	assert.NoError(t, err)
+	tor := &Torrent{Data: data, FileInfo: &util.File{Path: "test.torrent"}}
+	assert.NoError(t, tor.Load())
+	assert.True(t, tor.Info.IsPrivate())
+	assert.Equal(t, []WebSeedURL{{"http://a/", WebSeedTypeURLList}, {"http://c/seed", WebSeedTypeHTTPSeeds}}, WebSeeds(tor))
+
+	_, err = svc.IndexTorrent(ctx, tor)
+	assert.NoError(t, err)
+
+	var tt models.Torrent
+	assert.NoError(t, svc.DB.First(&tt).Error)
+	assert.True(t, tt.Private)
+	assert.Equal(t, "SRC", tt.Source)
+	assert.True(t, tt.HasWebSeed)
+
+	ws, err := LoadWebSeeds(ctx, svc.DB, []string{tt.Hash})
+	assert.NoError(t, err)
+	assert.Len(t, ws[tt.Hash], 2)
+	nodes, err := LoadNodes(ctx, svc.DB, []string{tt.Hash})
+	assert.NoError(t, err)
+	assert.Len(t, nodes[tt.Hash], 1)
+	assert.Equal(t, "router.example.com:6881", nodes[tt.Hash][0].String())
+
+	ms, err := LoadMagnets(ctx, svc.DB, []*models.Torrent{&tt})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://a/"}, ms[tt.Hash].WebSeeds)
+
+	// force rebuild from meta without seeds
+	data, err = bencode.EncodeBytes(map[string]interface{}{"info": tor.Meta.InfoBytes})
+	assert.NoError(t, err)
+	tor = &Torrent{Data: data, FileInfo: &util.File{Path: "test.torrent"}}
+	assert.NoError(t, tor.Load())
+	_, err = svc.IndexTorrent(ctx, tor, func(o *IndexTorrentOptions) {
+		o.Force = true
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DB.First(&tt).Error)
+	assert.False(t, tt.HasWebSeed)
+	ws, err = LoadWebSeeds(ctx, svc.DB, []string{tt.Hash})
+	assert.NoError(t, err)
+	assert.Empty(t, ws[tt.Hash])
+	nodes, err = LoadNodes(ctx, svc.DB, []string{tt.Hash})
+	assert.NoError(t, err)
+	assert.Empty(t, nodes[tt.Hash])
+}
@@ -0,0 +1,95 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/wenerme/torrenti/pkg/search"
+	"github.com/wenerme/torrenti/pkg/torrenti"
+	"github.com/wenerme/torrenti/pkg/torrenti/util"
+)
+
+// MaxExportSearchLimit limit number of search hits when export by query
+const MaxExportSearchLimit = 10000
+
+type NewExportHandlerOptions struct {
+	Indexer *torrenti.Service
+	Search  *search.Service
+}
+
+// NewExportHandler stream exported torrents as tar or zip
+//
+// query: hash (repeated, narrowed to matched torrents when search given), search, since, until, referer, limit, format=zip|tar
+func NewExportHandler(o NewExportHandlerOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		format := q.Get("format")
+		if format == "" {
+			format = "zip"
+		}
+		if format != "zip" && format != "tar" {
+			http.Error(w, "invalid format", http.StatusBadRequest)
+			return
+		}
+
+		opts := torrenti.ExportOptions{
+			Hashes:        q["hash"],
+			RefererDomain: q.Get("referer"),
+		}
+		var err error
+		if v := q.Get("limit"); v != "" {
+			if opts.Limit, err = strconv.Atoi(v); err != nil {
+				http.Error(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+		}
+		for k, t := range map[string]*time.Time{"since": &opts.Since, "until": &opts.Until} {
+			if v := q.Get(k); v != "" {
+				if *t, err = util.ParseTime(v); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+		}
+		if s := q.Get("search"); s != "" {
+			if o.Search == nil {
+				http.Error(w, "search not available", http.StatusNotImplemented)
+				return
+			}
+			limit := opts.Limit
+			if limit <= 0 || limit > MaxExportSearchLimit {
+				limit = MaxExportSearchLimit
+			}
+			hashes, err := torrenti.SearchTorrentHashes(r.Context(), o.Search, s, limit)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			opts.Hashes = torrenti.SearchedHashes(opts.Hashes, hashes)
+		}
+
+		ew, err := torrenti.NewExportWriter(format, w, "")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=torrents-%s.%s", time.Now().Format("20060102150405"), format))
+		if format == "zip" {
+			w.Header().Set("Content-Type", "application/zip")
+		} else {
+			w.Header().Set("Content-Type", "application/x-tar")
+		}
+		// header already sent, can only abort the stream
+		err = o.Indexer.ExportTorrent(r.Context(), opts, ew.WriteFile)
+		if err == nil {
+			err = ew.Close()
+		}
+		if err != nil {
+			log.Err(err).Msg("export torrent")
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
@@ -2,12 +2,11 @@ package web
 
 import (
 	"context"
-	"encoding/json"
-	"github.com/xgfone/bt/bencode"
 	"google.golang.org/protobuf/types/known/structpb"
 	"strings"
 
 	"github.com/blugelabs/bluge/search/highlight"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/wenerme/torrenti/pkg/torrenti"
 
@@ -18,10 +17,12 @@ import (
 
 	"github.com/samber/lo"
 	webv1 "github.com/wenerme/torrenti/pkg/apis/media/web/v1"
+	"github.com/wenerme/torrenti/pkg/magnet"
 	"github.com/wenerme/torrenti/pkg/scrape/handlers"
 	"github.com/wenerme/torrenti/pkg/torrenti/models"
 	"github.com/wenerme/torrenti/pkg/torrenti/util/nilx"
 	"github.com/wenerme/torrenti/pkg/torrenti/util/protox"
+	"github.com/xgfone/bt/metainfo"
 	"gorm.io/gorm"
 )
 
@@ -67,7 +68,16 @@ func (s *webServiceServer) SearchTorrentRef(ctx context.Context, req *webv1.Sear
 		QueryString: req.Search,
 		Limit:       int(req.Limit),
 		Offset:      int(req.Offset),
+		Private:     nilx.Bool(false),
+		Source:      req.Source,
+		HasWebSeed:  nilx.FalseToNil(req.HasWebSeed),
+		Filters:     req.Filters,
+		Facets:      true,
 	})
+	var qe *search.QueryError
+	if errors.As(err, &qe) {
+		return nil, status.Error(codes.InvalidArgument, qe.Error())
+	}
 	if err != nil {
 		return
 	}
@@ -75,6 +85,20 @@ func (s *webServiceServer) SearchTorrentRef(ctx context.Context, req *webv1.Sear
 		Items:    nil,
 		Total:    int32(sr.Count),
 		Duration: int32(sr.Duration.Milliseconds()),
+		Facets: lo.Map(sr.Facets, func(f *search.Facet, i int) *webv1.SearchFacet {
+			return &webv1.SearchFacet{
+				Name: f.Name,
+				Values: lo.Map(f.Values, func(v *search.FacetValue, i int) *webv1.SearchFacetValue {
+					return &webv1.SearchFacetValue{Value: v.Value, Count: int32(v.Count)}
+				}),
+			}
+		}),
+	}
+	dups := map[string]int{}
+	if req.CollapseDuplicates {
+		if sr.Docs, dups, err = s.collapseDuplicates(ctx, sr.Docs); err != nil {
+			return
+		}
 	}
 	ids := lo.Map(sr.Docs, func(t *search.DocumentMatch, i int) string {
 		return t.ID
@@ -91,12 +115,13 @@ func (s *webServiceServer) SearchTorrentRef(ctx context.Context, req *webv1.Sear
 	var out []*models.MetaFile
 	err = s.DB.Model(models.MetaFile{}).
 		Where("torrent_hash in (?)", ids).
+		Scopes(s.publicMetaFile).
 		Select([]string{
 			"filename", "content_hash", "torrent_hash", "creation_date",
 			"comment", "created_by",
 		}).
 		Preload("Torrent", func(db *gorm.DB) *gorm.DB {
-			return db.Select([]string{"name", "hash", "total_file_size", "file_count", "is_dir"})
+			return db.Select([]string{"name", "hash", "hash_v2", "total_file_size", "file_count", "is_dir", "private", "source", "charset"})
 		}).
 		Find(&out).Error
 	if err != nil {
@@ -123,6 +148,7 @@ func (s *webServiceServer) SearchTorrentRef(ctx context.Context, req *webv1.Sear
 		vv := toItem(v)
 		if vv != nil {
 			vv.Item.TorrentHash = ""
+			vv.Duplicates = int32(dups[v.Match.ID])
 			// debug invalid utf8
 			//_, err := protojson.Marshal(vv)
 			//if err != nil {
@@ -131,6 +157,9 @@ func (s *webServiceServer) SearchTorrentRef(ctx context.Context, req *webv1.Sear
 			resp.Items = append(resp.Items, vv)
 		}
 	}
+	err = s.fillTorrent(ctx, lo.Map(resp.Items, func(t *webv1.SearchTorrentRef, i int) *webv1.Torrent {
+		return t.Item.Torrent
+	})...)
 	return
 }
 
@@ -144,6 +173,9 @@ func toItem(t *torrenti.TorrentSearchMatch) *webv1.SearchTorrentRef {
 		Item:                 toTorrentRef(t.Model, 0),
 		HighlightFileName:    t.HighlightFileName,
 		HighlightTorrentName: t.HighlightTorrentName,
+		Files: lo.Map(t.Match.Files, func(v *search.FileMatch, i int) *webv1.SearchFileMatch {
+			return &webv1.SearchFileMatch{Path: v.Path, Highlight: v.Highlight}
+		}),
 	}
 }
 
@@ -152,7 +184,7 @@ func (s *webServiceServer) ListTorrentRef(ctx context.Context, req *webv1.ListTo
 	se := strings.TrimSpace(req.Search)
 	pageSize := 100
 	offset := int(req.GetPage()) * pageSize
-	query := s.DB.Preload("Torrent")
+	query := s.DB.Preload("Torrent").Scopes(s.publicMetaFile)
 
 	var sr *search.SearchResponse
 	if se != "" && s.Search != nil {
@@ -160,6 +192,7 @@ func (s *webServiceServer) ListTorrentRef(ctx context.Context, req *webv1.ListTo
 			QueryString: se,
 			Limit:       pageSize,
 			Offset:      offset,
+			Private:     nilx.Bool(false),
 		})
 		if err != nil {
 			return
@@ -187,6 +220,9 @@ func (s *webServiceServer) ListTorrentRef(ctx context.Context, req *webv1.ListTo
 			return m[i.FileHash] < m[j.FileHash]
 		})
 	}
+	err = s.fillTorrent(ctx, lo.Map(resp.Items, func(t *webv1.TorrentRef, i int) *webv1.Torrent {
+		return t.Torrent
+	})...)
 	return
 }
 
@@ -204,20 +240,25 @@ func (s *webServiceServer) GetTorrentRefData(ctx context.Context, req *webv1.Get
 	resp = &webv1.GetTorrentRefDataResponse{
 		Item: toTorrentRef(out, 0),
 	}
-	d := map[string]interface{}{}
-	err = json.Unmarshal(out.Raw, &d)
-	if err != nil {
+	if err = s.fillTorrent(ctx, resp.Item.Torrent); err != nil {
 		return
 	}
-
-	d = toInt(d).(map[string]interface{})
-	d["info"] = bencode.RawMessage(out.Torrent.InfoBytes)
-	resp.Data, err = bencode.EncodeBytes(d)
+	var exact bool
+	if resp.Data, exact, err = torrenti.LoadTorrentData(ctx, s.DB, out); err != nil {
+		err = status.Errorf(codes.DataLoss, "load torrent data: %v", err)
+	} else if !exact {
+		log.Warn().Str("content_hash", out.ContentHash).Msg("torrent data rebuilt, not byte exact")
+	}
 	return
 }
 func (s *webServiceServer) GetTorrentRef(ctx context.Context, req *webv1.GetTorrentRefRequest) (resp *webv1.GetTorrentRefResponse, err error) {
 	var out *models.Torrent
-	err = s.DB.Where(models.Torrent{Hash: req.GetHash()}).Find(&out).Error
+	h, err := magnet.ParseHash(req.GetHash())
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, "invalid hash: %v", err)
+		return
+	}
+	err = s.DB.Where("hash = ? or hash_v2 = ?", h.String(), h.String()).Find(&out).Error
 	if err == gorm.ErrRecordNotFound {
 		err = status.Errorf(codes.NotFound, "torrent not found")
 		return
@@ -225,6 +266,7 @@ func (s *webServiceServer) GetTorrentRef(ctx context.Context, req *webv1.GetTorr
 	resp = &webv1.GetTorrentRefResponse{
 		Item: toTorrent(out),
 	}
+	err = s.fillTorrent(ctx, resp.Item)
 	return
 }
 
@@ -233,13 +275,16 @@ func toTorrent(in *models.Torrent) (out *webv1.Torrent) {
 		return nil
 	}
 	out = &webv1.Torrent{
-		FileName: in.Name,
-		Hash:     in.Hash,
-		// Magnet:    in.Hash,
+		FileName:  in.Name,
+		Hash:      in.Hash,
+		HashV2:    in.HashV2,
 		FileSize:  in.TotalFileSize,
 		FileCount: int32(in.FileCount),
 		Ext:       "",
 		IsDir:     in.IsDir,
+		Private:   in.Private,
+		Source:    in.Source,
+		Charset:   in.Charset,
 	}
 	if !in.IsDir {
 		out.Ext = handlers.Ext(in.Name)
@@ -264,18 +309,242 @@ func toTorrentRef(in *models.MetaFile, idx int) *webv1.TorrentRef {
 	}
 }
 
-func toInt(in interface{}) interface{} {
-	switch vv := in.(type) {
-	case float64:
-		return int64(vv)
-	case []interface{}:
-		for i, v := range vv {
-			vv[i] = toInt(v)
+func (s *webServiceServer) ListTorrentFile(ctx context.Context, req *webv1.ListTorrentFileRequest) (resp *webv1.ListTorrentFileResponse, err error) {
+	h, err := magnet.ParseHash(req.GetHash())
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, "invalid hash: %v", err)
+		return
+	}
+	resp = &webv1.ListTorrentFileResponse{}
+	pageSize := 100
+	offset := int(req.GetPage()) * pageSize
+
+	var out []*models.TorrentFile
+	err = s.DB.WithContext(ctx).
+		Where("torrent_hash in (?)", s.DB.Model(models.Torrent{}).Select("hash").Where("hash = ? or hash_v2 = ?", h.String(), h.String())).
+		Order("path").Offset(offset).Limit(pageSize + 1).
+		Find(&out).Error
+	if err != nil {
+		return
+	}
+	if len(out) > pageSize {
+		resp.HasNext = true
+		out = out[:pageSize]
+	}
+	resp.Items = lo.Map(out, func(t *models.TorrentFile, i int) *webv1.TorrentFile {
+		return toTorrentFile(t)
+	})
+	return
+}
+
+func toTorrentFile(in *models.TorrentFile) *webv1.TorrentFile {
+	return &webv1.TorrentFile{
+		Path:        in.Path,
+		Size:        in.Size,
+		Attr:        in.Attr,
+		SymlinkPath: in.SymlinkPath,
+		Sha1:        in.SHA1,
+		PiecesRoot:  in.PiecesRoot,
+	}
+}
+
+func (s *webServiceServer) ListFileRootTorrent(ctx context.Context, req *webv1.ListFileRootTorrentRequest) (resp *webv1.ListFileRootTorrentResponse, err error) {
+	resp = &webv1.ListFileRootTorrentResponse{}
+	pageSize := 100
+	offset := int(req.GetPage()) * pageSize
+
+	root, err := torrenti.NormalizePiecesRoot(req.GetPiecesRoot())
+	if err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		return
+	}
+	out, err := torrenti.FindFileRoot(ctx, s.DB, root, offset, pageSize+1, s.publicMetaFile)
+	if err != nil {
+		return
+	}
+	if len(out) > pageSize {
+		resp.HasNext = true
+		out = out[:pageSize]
+	}
+	for _, v := range out {
+		resp.Items = append(resp.Items, &webv1.FileRootTorrent{
+			Torrent: toTorrent(v.Torrent),
+			File:    toTorrentFile(v.File),
+		})
+	}
+	err = s.fillTorrent(ctx, lo.Map(resp.Items, func(t *webv1.FileRootTorrent, i int) *webv1.Torrent {
+		return t.Torrent
+	})...)
+	return
+}
+
+func (s *webServiceServer) ListTracker(ctx context.Context, req *webv1.ListTrackerRequest) (resp *webv1.ListTrackerResponse, err error) {
+	resp = &webv1.ListTrackerResponse{}
+	pageSize := 100
+	offset := int(req.GetPage()) * pageSize
+
+	var out []struct {
+		URL          string
+		Protocol     string
+		TorrentCount int64
+	}
+	query := s.DB.WithContext(ctx).Model(models.Tracker{}).
+		Select("trackers.url, trackers.protocol, count(torrent_trackers.id) as torrent_count").
+		Joins("left join torrent_trackers on torrent_trackers.tracker_url = trackers.url").
+		Group("trackers.url, trackers.protocol").
+		Order("torrent_count desc, trackers.url").
+		Offset(offset).Limit(pageSize + 1)
+	if req.Protocol != "" {
+		query = query.Where("trackers.protocol = ?", strings.ToLower(req.Protocol))
+	}
+	if err = query.Scan(&out).Error; err != nil {
+		return
+	}
+	if len(out) > pageSize {
+		resp.HasNext = true
+		out = out[:pageSize]
+	}
+	for _, v := range out {
+		resp.Items = append(resp.Items, &webv1.Tracker{
+			Url:          v.URL,
+			Protocol:     v.Protocol,
+			TorrentCount: v.TorrentCount,
+		})
+	}
+	return
+}
+
+func (s *webServiceServer) ListTrackerTorrent(ctx context.Context, req *webv1.ListTrackerTorrentRequest) (resp *webv1.ListTrackerTorrentResponse, err error) {
+	tr, err := torrenti.NormalizeTracker(req.GetUrl())
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, "invalid tracker: %v", err)
+		return
+	}
+	resp = &webv1.ListTrackerTorrentResponse{}
+	pageSize := 100
+	offset := int(req.GetPage()) * pageSize
+
+	var out []*models.Torrent
+	err = s.DB.WithContext(ctx).
+		Select([]string{"name", "hash", "hash_v2", "total_file_size", "file_count", "is_dir", "private", "source", "charset"}).
+		Where("hash in (?)", s.DB.Model(models.TorrentTracker{}).Select("torrent_hash").Where(models.TorrentTracker{TrackerURL: tr.URL})).
+		Where("not private").
+		Order("id").Offset(offset).Limit(pageSize + 1).
+		Find(&out).Error
+	if err != nil {
+		return
+	}
+	if len(out) > pageSize {
+		resp.HasNext = true
+		out = out[:pageSize]
+	}
+	resp.Items = lo.Map(out, func(t *models.Torrent, i int) *webv1.Torrent {
+		return toTorrent(t)
+	})
+	err = s.fillTorrent(ctx, resp.Items...)
+	return
+}
+
+func (s *webServiceServer) ListDuplicateTorrent(ctx context.Context, req *webv1.ListDuplicateTorrentRequest) (resp *webv1.ListDuplicateTorrentResponse, err error) {
+	h, err := magnet.ParseHash(req.GetHash())
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, "invalid hash: %v", err)
+		return
+	}
+	var t models.Torrent
+	if err = s.DB.WithContext(ctx).Select("hash").Where("hash = ? or hash_v2 = ?", h.String(), h.String()).Limit(1).Find(&t).Error; err != nil {
+		return
+	}
+	if t.Hash == "" {
+		err = status.Errorf(codes.NotFound, "torrent not found")
+		return
+	}
+	resp = &webv1.ListDuplicateTorrentResponse{}
+	groups, err := torrenti.LoadDuplicateGroups(ctx, s.DB, []string{t.Hash})
+	if err != nil || groups[t.Hash] == "" {
+		return
+	}
+	resp.GroupHash = groups[t.Hash]
+
+	var out []*models.Torrent
+	err = s.DB.WithContext(ctx).
+		Select([]string{"name", "hash", "hash_v2", "total_file_size", "file_count", "is_dir", "private", "source", "charset"}).
+		Where("hash in (?)", s.DB.Model(models.TorrentDuplicate{}).Select("torrent_hash").Where(models.TorrentDuplicate{GroupHash: resp.GroupHash})).
+		Where("hash != ? and not private", t.Hash).
+		Order("id").
+		Find(&out).Error
+	if err != nil {
+		return
+	}
+	resp.Items = lo.Map(out, func(t *models.Torrent, i int) *webv1.Torrent {
+		return toTorrent(t)
+	})
+	err = s.fillTorrent(ctx, resp.Items...)
+	return
+}
+
+// collapseDuplicates keep first match of each duplicate group, return collapsed count of kept match
+func (s *webServiceServer) collapseDuplicates(ctx context.Context, docs []*search.DocumentMatch) ([]*search.DocumentMatch, map[string]int, error) {
+	dups := map[string]int{}
+	groups, err := torrenti.LoadDuplicateGroups(ctx, s.DB, lo.Map(docs, func(t *search.DocumentMatch, i int) string {
+		return t.ID
+	}))
+	if err != nil {
+		return docs, dups, err
+	}
+	first := map[string]string{}
+	out := docs[:0:0]
+	for _, v := range docs {
+		g := groups[v.ID]
+		if g == "" {
+			out = append(out, v)
+			continue
 		}
-	case map[string]interface{}:
-		for k, v := range vv {
-			vv[k] = toInt(v)
+		if id, ok := first[g]; ok {
+			dups[id]++
+			continue
 		}
+		first[g] = v.ID
+		out = append(out, v)
+	}
+	return out, dups, nil
+}
+
+// publicMetaFile exclude meta files of private torrent from listing
+func (s *webServiceServer) publicMetaFile(db *gorm.DB) *gorm.DB {
+	return db.Where("torrent_hash not in (?)", s.DB.Model(models.Torrent{}).Select("hash").Where("private"))
+}
+
+// fillTorrent fill magnet, web seeds and nodes of stored torrents
+func (s *webServiceServer) fillTorrent(ctx context.Context, items ...*webv1.Torrent) error {
+	items = lo.Filter(items, func(t *webv1.Torrent, i int) bool {
+		return t != nil && t.Hash != ""
+	})
+	ms, err := torrenti.LoadMagnets(ctx, s.DB, lo.Map(items, func(t *webv1.Torrent, i int) *models.Torrent {
+		return &models.Torrent{Hash: t.Hash, HashV2: t.HashV2, Name: t.FileName, TotalFileSize: t.FileSize}
+	}))
+	if err != nil {
+		return err
+	}
+	hashes := lo.Map(items, func(t *webv1.Torrent, i int) string {
+		return t.Hash
+	})
+	webSeeds, err := torrenti.LoadWebSeeds(ctx, s.DB, hashes)
+	if err != nil {
+		return err
+	}
+	nodes, err := torrenti.LoadNodes(ctx, s.DB, hashes)
+	if err != nil {
+		return err
+	}
+	for _, v := range items {
+		v.Magnet = ms[v.Hash].String()
+		v.WebSeeds = lo.Map(webSeeds[v.Hash], func(t torrenti.WebSeedURL, i int) string {
+			return t.URL
+		})
+		v.Nodes = lo.Map(nodes[v.Hash], func(t metainfo.HostAddress, i int) string {
+			return t.String()
+		})
 	}
-	return in
+	return nil
 }